@@ -0,0 +1,6 @@
+#!/bin/sh
+
+### cloud-init ###
+# wait for the bootstrap to finish; recoverable errors (exit code 2) are tolerated
+cloud-init status --wait > /dev/null
+[ $? -ne 1 ]
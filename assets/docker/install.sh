@@ -19,12 +19,3 @@ DEBIAN_FRONTEND=noninteractive apt-get update
 
 # install docker
 DEBIAN_FRONTEND=noninteractive apt-get install --yes docker-ce docker-ce-cli containerd.io docker-buildx-plugin docker-compose-plugin
-
-# daemon.json
-cat << EOF > /etc/docker/daemon.json
-{{ .daemonJson }}
-EOF
-
-# start docker
-systemctl enable docker
-systemctl restart docker
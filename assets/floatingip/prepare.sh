@@ -0,0 +1,8 @@
+#!/bin/sh
+
+### floating IPs ###
+# install keepalived
+DEBIAN_FRONTEND=noninteractive sudo apt-get install -y keepalived curl
+
+# create directories
+sudo mkdir -p /opt/floatingip || true
@@ -2,7 +2,7 @@
 
 ### gre ###
 # disable autoconf for ipv6
-cat <<EOF > /etc/sysctl.d/99-disable-ipv6-autoconf.conf
+cat <<EOF | sudo tee /etc/sysctl.d/99-disable-ipv6-autoconf.conf > /dev/null
 net.ipv6.conf.default.autoconf = 0
 net.ipv6.conf.default.accept_ra = 0
 net.ipv6.conf.all.autoconf = 0
@@ -1,13 +1,13 @@
 #!/bin/sh
 
 ### frr ###
-systemctl daemon-reload
-systemctl enable frr
-systemctl restart frr
+sudo systemctl daemon-reload
+sudo systemctl enable frr
+sudo systemctl restart frr
 
 # finalize installation
-echo "installed" > /opt/frr.state
+echo "installed" | sudo tee /opt/frr.state > /dev/null
 
 # cleanup old images
 sleep 90
-docker image prune --all --force || true
+sudo docker image prune --all --force || true
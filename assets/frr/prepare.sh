@@ -2,4 +2,4 @@
 
 ### frr ###
 # create directories
-mkdir -p /opt/frr/config || true
+sudo mkdir -p /opt/frr/config || true
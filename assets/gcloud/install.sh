@@ -1,4 +1,4 @@
 #!/bin/sh
 
 ### gcloud ###
-gcloud auth login --cred-file=/opt/google/credentials.json
+sudo gcloud auth login --cred-file=/opt/google/credentials.json
@@ -0,0 +1,16 @@
+#!/bin/sh
+set -e
+
+### nftables ###
+# only a connection opened after the ruleset was applied confirms it
+APPLIED="$(cat /opt/nftables/applied 2>/dev/null || echo 0)"
+CONNECTED="$(( $(date +%s) - $(ps -o etimes= -p "$PPID") ))"
+if [ "$APPLIED" -eq 0 ] || [ "$CONNECTED" -lt "$APPLIED" ]; then
+    echo "the SSH connection was not opened after the ruleset was applied" >&2
+    exit 1
+fi
+
+# confirm the applied ruleset
+sudo systemctl stop nftables-rollback.timer 2>/dev/null || true
+echo "confirmed" | sudo tee /opt/nftables/confirmed > /dev/null
+sudo rm -f /opt/nftables/applied /opt/nftables/ruleset.nft.previous
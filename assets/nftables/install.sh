@@ -0,0 +1,36 @@
+#!/bin/sh
+set -e
+
+### nftables ###
+# validate the pending ruleset
+sudo nft --check --file /opt/nftables/ruleset.nft.pending
+
+# keep the active ruleset for the rollback
+if [ -f /opt/nftables/ruleset.nft ]; then
+    sudo cp /opt/nftables/ruleset.nft /opt/nftables/ruleset.nft.previous
+else
+    sudo rm -f /opt/nftables/ruleset.nft.previous
+fi
+
+# roll back unless confirmed
+sudo systemctl stop nftables-rollback.timer 2>/dev/null || true
+sudo rm -f /opt/nftables/applied /opt/nftables/confirmed
+sudo systemd-run --unit=nftables-rollback --on-active=120 /opt/nftables/rollback.sh
+
+# apply the ruleset atomically
+sudo nft --file /opt/nftables/ruleset.nft.pending
+sudo cp /opt/nftables/ruleset.nft.pending /opt/nftables/ruleset.nft
+date +%s | sudo tee /opt/nftables/applied > /dev/null
+
+# load the ruleset on boot
+sudo systemctl daemon-reload
+sudo systemctl enable nftables
+
+# wait for the confirmation via a new SSH connection; fail if the ruleset was rolled back
+while [ ! -f /opt/nftables/confirmed ]; do
+    if ! systemctl is-active -q nftables-rollback.timer; then
+        echo "the ruleset was not confirmed and has been rolled back" >&2
+        exit 1
+    fi
+    sleep 2
+done
@@ -0,0 +1,8 @@
+#!/bin/sh
+
+### nftables ###
+# install nftables
+DEBIAN_FRONTEND=noninteractive sudo apt-get install -y nftables
+
+# create directories
+sudo mkdir -p /opt/nftables || true
@@ -0,0 +1,12 @@
+#!/bin/sh
+
+### nftables ###
+# restore the previous ruleset, or remove the host firewall if there was none
+if [ -f /opt/nftables/ruleset.nft.previous ]; then
+    nft --file /opt/nftables/ruleset.nft.previous
+    mv /opt/nftables/ruleset.nft.previous /opt/nftables/ruleset.nft
+else
+    nft delete table inet host || true
+    rm -f /opt/nftables/ruleset.nft
+fi
+rm -f /opt/nftables/applied
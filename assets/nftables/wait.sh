@@ -0,0 +1,10 @@
+#!/bin/sh
+
+### nftables ###
+# wait for the ruleset to be applied, so that the confirmation opens its SSH connection afterwards
+for _ in $(seq 1 60); do
+    [ -f /opt/nftables/applied ] && exit 0
+    sleep 2
+done
+echo "the ruleset was not applied" >&2
+exit 1
@@ -1,5 +1,9 @@
 #!/bin/sh
 
 ### scaleway ###
-curl -s https://raw.githubusercontent.com/scaleway/scaleway-cli/master/scripts/get.sh | sh
-apt-get install -y rclone
+ARCH=$(dpkg --print-architecture)
+VERSION=$(curl -fsS https://api.github.com/repos/scaleway/scaleway-cli/releases/latest | jq -r '.tag_name' | sed 's/^v//')
+curl -fsSL -o /tmp/scw "https://github.com/scaleway/scaleway-cli/releases/download/v${VERSION}/scaleway-cli_${VERSION}_linux_${ARCH}"
+sudo install -m 0755 /tmp/scw /usr/local/bin/scw
+rm -f /tmp/scw
+sudo apt-get install -y rclone
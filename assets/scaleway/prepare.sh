@@ -2,4 +2,4 @@
 
 ### scaleway ###
 # create directories
-mkdir -p /opt/scaleway || true
+sudo mkdir -p /opt/scaleway || true
@@ -0,0 +1,6 @@
+#!/bin/sh
+
+### cron ###
+DEBIAN_FRONTEND=noninteractive sudo apt-get install -y curl jq
+sudo systemctl daemon-reload
+sudo systemctl restart cron
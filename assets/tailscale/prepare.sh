@@ -2,4 +2,4 @@
 
 ### tailscale ###
 # create directories
-mkdir -p /opt/tailscale/state || true
+sudo mkdir -p /opt/tailscale/state || true
@@ -0,0 +1,22 @@
+#!/bin/sh
+
+### logrotate ###
+cat << EOF | sudo tee /etc/logrotate.d/traefik-access > /dev/null
+/opt/traefik/logs/access.log {
+    daily
+    rotate 7
+    maxsize 100M
+    dateext
+    dateformat -%Y%m%d-%s
+    compress
+    missingok
+    notifempty
+    postrotate
+        docker kill --signal=USR1 traefik > /dev/null 2>&1 || true
+    endscript
+}
+EOF
+
+### cron ###
+sudo systemctl daemon-reload
+sudo systemctl restart cron
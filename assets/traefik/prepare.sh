@@ -2,4 +2,7 @@
 
 ### traefik ###
 # create directories
-mkdir -p /opt/traefik || true
+sudo mkdir -p /opt/traefik || true
+sudo mkdir -p /opt/traefik/dynamic || true
+sudo mkdir -p /opt/traefik/certs || true
+sudo mkdir -p /opt/traefik/logs || true
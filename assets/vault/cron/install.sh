@@ -0,0 +1,21 @@
+#!/bin/sh
+
+### logrotate ###
+cat << EOF | sudo tee /etc/logrotate.d/vault-audit > /dev/null
+/opt/vault/audit/audit.log {
+    daily
+    rotate 7
+    dateext
+    dateformat -%Y%m%d
+    compress
+    missingok
+    notifempty
+    postrotate
+        docker kill --signal=HUP vault > /dev/null 2>&1 || true
+    endscript
+}
+EOF
+
+### cron ###
+sudo systemctl daemon-reload
+sudo systemctl restart cron
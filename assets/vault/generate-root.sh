@@ -0,0 +1,36 @@
+#!/bin/bash
+
+# Generates a new root token using the recovery keys (break-glass).
+# The recovery keys are prompted for one at a time until the threshold is reached.
+
+set -eo pipefail
+
+
+ADDRESS="http://127.0.0.1:8200"
+
+vault() {
+    sudo docker exec -i -e VAULT_ADDR="${ADDRESS}" vault vault "$@"
+}
+
+
+# start the root token generation
+INIT=$(vault operator generate-root -init -format=json)
+NONCE=$(echo "${INIT}" | jq -r '.nonce')
+OTP=$(echo "${INIT}" | jq -r '.otp')
+echo "Root token generation started (nonce: ${NONCE})."
+
+
+# provide the recovery keys
+ENCODED_TOKEN=""
+while [[ -z "${ENCODED_TOKEN}" ]]; do
+    read -r -s -p "Recovery key: " RECOVERY_KEY
+    echo
+    STATUS=$(echo "${RECOVERY_KEY}" | vault operator generate-root -nonce="${NONCE}" -format=json -)
+    ENCODED_TOKEN=$(echo "${STATUS}" | jq -r '.encoded_token // empty')
+    echo "Progress: $(echo "${STATUS}" | jq -r '.progress')/$(echo "${STATUS}" | jq -r '.required')"
+done
+
+
+# decode the root token
+echo "Root token:"
+vault operator generate-root -decode="${ENCODED_TOKEN}" -otp="${OTP}"
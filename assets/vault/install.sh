@@ -1,13 +1,13 @@
 #!/bin/sh
 
 ### vault ###
-systemctl daemon-reload
-systemctl enable vault
-systemctl restart vault
+sudo systemctl daemon-reload
+sudo systemctl enable vault
+sudo systemctl restart vault
 
 # finalize installation
-echo "installed" > /opt/vault.state
+echo "installed" | sudo tee /opt/vault.state > /dev/null
 
 # cleanup old images
 sleep 90
-docker image prune --all --force || true
+sudo docker image prune --all --force || true
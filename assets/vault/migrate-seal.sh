@@ -0,0 +1,46 @@
+#!/bin/bash
+
+# Migrates the Vault seal: Vault starts in migration mode after the installer rendered the
+# previous seal disabled, and is unsealed with the migration keys passed via stdin.
+
+set -eo pipefail
+
+
+ADDRESS="http://127.0.0.1:8200"
+
+vault() {
+    sudo docker exec -i -e VAULT_ADDR="${ADDRESS}" vault vault "$@"
+}
+
+
+# wait for vault to be reachable
+while [[ ! $(curl -s ${ADDRESS}) ]]; do
+    echo "Waiting for vault to be reachable..."
+    sleep 5
+done
+
+# auto-unseal to auto-unseal migrations are performed by vault on startup
+STATUS=$(vault status -format=json < /dev/null || true)
+if [[ $(echo "${STATUS}" | jq -r '.sealed') != "true" ]]; then
+    echo "Vault is unsealed. Nothing to migrate..."
+    exit 0
+fi
+if [[ $(echo "${STATUS}" | jq -r '.migration') != "true" ]]; then
+    echo "Vault is sealed but not in migration mode. Check the seal configuration."
+    exit 1
+fi
+
+
+# provide the keys until vault is unsealed
+while read -r KEY; do
+    [[ -z "${KEY}" ]] && continue
+    STATUS=$(echo "${KEY}" | vault operator unseal -migrate -format=json -)
+    echo "Progress: $(echo "${STATUS}" | jq -r '.progress')/$(echo "${STATUS}" | jq -r '.t')"
+    if [[ $(echo "${STATUS}" | jq -r '.sealed') == "false" ]]; then
+        echo "Seal migration completed."
+        exit 0
+    fi
+done
+
+echo "Not enough keys provided to complete the seal migration. Set the migrationKeys of the seal configuration."
+exit 1
@@ -2,4 +2,9 @@
 
 ### vault ###
 # create directories
-mkdir -p /opt/vault/config || true
+sudo mkdir -p /opt/vault/config || true
+sudo mkdir -p /opt/vault/audit || true
+sudo mkdir -p /opt/vault/hsm || true
+
+# install dependencies for the break-glass scripts
+DEBIAN_FRONTEND=noninteractive sudo apt-get install --yes jq
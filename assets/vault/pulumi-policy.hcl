@@ -0,0 +1,74 @@
+# child tokens of the provider
+path "auth/token/create" {
+  capabilities = ["update"]
+}
+
+# policies; the pulumi policy itself is managed by the bootstrap
+path "sys/policy/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+path "sys/policies/acl/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+path "sys/policy/pulumi" {
+  capabilities = ["read"]
+}
+path "sys/policies/acl/pulumi" {
+  capabilities = ["read"]
+}
+
+# auth methods; the pulumi auth method itself is managed by the bootstrap
+path "sys/auth" {
+  capabilities = ["read"]
+}
+path "sys/auth/*" {
+  capabilities = ["create", "read", "update", "delete", "sudo"]
+}
+path "sys/mounts/auth/*" {
+  capabilities = ["read", "update"]
+}
+path "sys/auth/pulumi" {
+  capabilities = ["read"]
+}
+path "sys/mounts/auth/pulumi/*" {
+  capabilities = ["read"]
+}
+path "auth/approle/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+path "auth/github/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+# audit devices
+path "sys/audit" {
+  capabilities = ["read", "sudo"]
+}
+path "sys/audit/*" {
+  capabilities = ["create", "read", "update", "delete", "sudo"]
+}
+
+# secrets engines
+path "sys/mounts" {
+  capabilities = ["read"]
+}
+path "sys/mounts/*" {
+  capabilities = ["create", "read", "update", "delete"]
+}
+path "pki/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+path "pki-intermediate/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+path "transit/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+path "ssh/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
+
+# secrets of Vault and the other services
+path "vault/*" {
+  capabilities = ["create", "read", "update", "delete", "list"]
+}
@@ -0,0 +1,40 @@
+#!/bin/bash
+
+# Unseals Vault with the Shamir unseal keys passed via stdin, e.g., after a restart or reinstallation.
+
+set -eo pipefail
+
+
+ADDRESS="http://127.0.0.1:8200"
+
+vault() {
+    sudo docker exec -i -e VAULT_ADDR="${ADDRESS}" vault vault "$@"
+}
+
+
+# wait for vault to be reachable
+while [[ ! $(curl -s ${ADDRESS}) ]]; do
+    echo "Waiting for vault to be reachable..."
+    sleep 5
+done
+
+STATUS=$(vault status -format=json < /dev/null || true)
+if [[ $(echo "${STATUS}" | jq -r '.sealed') != "true" ]]; then
+    echo "Vault is unsealed. Skipping..."
+    exit 0
+fi
+
+
+# provide the keys until vault is unsealed
+while read -r KEY; do
+    [[ -z "${KEY}" ]] && continue
+    STATUS=$(echo "${KEY}" | vault operator unseal -format=json -)
+    echo "Progress: $(echo "${STATUS}" | jq -r '.progress')/$(echo "${STATUS}" | jq -r '.t')"
+    if [[ $(echo "${STATUS}" | jq -r '.sealed') == "false" ]]; then
+        echo "Vault unsealed."
+        exit 0
+    fi
+done
+
+echo "Vault is sealed and could not be unsealed: not enough unseal keys available. Set vault.seal.unsealKeys or unseal Vault manually."
+exit 1
@@ -1,6 +1,5 @@
 #!/bin/sh
 
 ### cron ###
-chmod +x /bin/wireguard-backup
-systemctl daemon-reload
-systemctl restart cron
+sudo systemctl daemon-reload
+sudo systemctl restart cron
@@ -2,6 +2,6 @@
 
 ### wireguard ###
 # create directories
-mkdir -p /opt/wireguard/config || true
-mkdir -p /opt/wireguard/data || true
-mkdir -p /opt/wireguard/etc || true
+sudo mkdir -p /opt/wireguard/config || true
+sudo mkdir -p /opt/wireguard/data || true
+sudo mkdir -p /opt/wireguard/etc || true
@@ -8,28 +8,36 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"gopkg.in/yaml.v3"
 
-	"github.com/muhlba91/pulumi-shared-library/pkg/lib/tls"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/dir"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/storage"
 	scwUpload "github.com/muhlba91/pulumi-shared-library/pkg/util/storage/scaleway"
 	tlsProv "github.com/pulumi/pulumi-tls/sdk/v5/go/tls"
 
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/access"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/cloudinit"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/dns"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/docker"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/floatingip"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/frr"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/gcloud"
-	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/google/dns"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/google/serviceaccount"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/hetzner/server"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/nftables"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/scaleway"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/scaleway/application"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/snapshot"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/tailscale"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/traefik"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/vault"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/volume"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/wireguard"
+	dnsModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/dns"
 	serverModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+	traefikModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/traefik"
 	vaultModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
 	wireguardModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/wireguard"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
 )
 
 //nolint:gocognit,funlen // main is the entry point of the Pulumi program.
@@ -41,34 +49,148 @@ func main() {
 		}
 
 		// configuration
-		googleConfig, scalewayConfig, serverConfig, networkConfig, oidcConfig, dnsConfig, bgpConfig, tailscaleConfig, err := config.LoadConfig(
+		googleConfig, scalewayConfig, serverConfig, networkConfig, oidcConfig, dnsConfig, bgpConfig, tailscaleConfig, vaultConfig, traefikConfig, err := config.LoadConfig(
 			ctx,
 		)
 		if err != nil {
 			return err
 		}
 
+		// connections
+		proxy, bErr := install.Proxy(serverConfig.Bastion, config.BastionPrivateKey(ctx))
+		if bErr != nil {
+			return bErr
+		}
+
 		// instance
-		sshKey, sErr := tls.CreateSSHKey(ctx, fmt.Sprintf("core-%s", config.Environment), 0)
+		sshKeys, sErr := access.CreateKeys(ctx, serverConfig)
 		if sErr != nil {
 			return sErr
 		}
-		instance, iErr := server.Create(ctx, sshKey.PublicKeyOpenssh, serverConfig, networkConfig)
+		sshKey := sshKeys.Current
+		instance, iErr := server.Create(ctx, sshKey.PublicKeyOpenssh, serverConfig, networkConfig, dnsConfig, bgpConfig)
 		if iErr != nil {
 			return iErr
 		}
 		dependsOn := []pulumi.Resource{instance.Resource}
 
 		// dns
-		dnsEntries := dns.Create(ctx, dnsConfig, instance.PublicIPv4, instance.PublicIPv6)
-		dependsOn = append(dependsOn, dnsEntries...)
+		dnsData, dnsErr := dns.Create(
+			ctx,
+			dnsConfig,
+			scalewayConfig,
+			instance,
+			traefik.CertificateAuthorities(traefikConfig),
+		)
+		if dnsErr != nil {
+			return dnsErr
+		}
+		dependsOn = append(dependsOn, dnsData.Resources...)
+
+		// cloud-init
+		cloudInitWait, ciErr := cloudinit.Wait(
+			ctx,
+			instance.SSHIPv4,
+			sshKey.PrivateKeyPem,
+			proxy,
+			instance.ID,
+			pulumi.DependsOn(dependsOn),
+		)
+		if ciErr != nil {
+			return ciErr
+		}
+		dependsOn = append(dependsOn, cloudInitWait)
+
+		// access
+		accessInstall, acErr := access.Install(
+			ctx,
+			instance.SSHIPv4,
+			instance.ID,
+			sshKeys,
+			proxy,
+			pulumi.DependsOn(dependsOn),
+		)
+		if acErr != nil {
+			return acErr
+		}
+		dependsOn = append(dependsOn, accessInstall)
+
+		// volumes
+		volumeMounts, vmErr := volume.Install(
+			ctx,
+			instance.SSHIPv4,
+			sshKey.PrivateKeyPem,
+			proxy,
+			instance.Volumes,
+			pulumi.DependsOn(dependsOn),
+		)
+		if vmErr != nil {
+			return vmErr
+		}
+		dependsOn = append(dependsOn, volumeMounts...)
+
+		// host firewall
+		nftablesInstall, nfErr := nftables.Install(
+			ctx,
+			instance.SSHIPv4,
+			sshKey.PrivateKeyPem,
+			proxy,
+			networkConfig,
+			serverConfig,
+			bgpConfig,
+			pulumi.DependsOn(dependsOn),
+		)
+		if nfErr != nil {
+			return nfErr
+		}
+		if nftablesInstall != nil {
+			dependsOn = append(dependsOn, nftablesInstall)
+		}
+
+		// floating IPs
+		floatingIPInstall, fiErr := floatingip.Install(
+			ctx,
+			instance.SSHIPv4,
+			sshKey.PrivateKeyPem,
+			proxy,
+			instance.FloatingIPs,
+			serverConfig,
+			config.HetznerToken(ctx),
+			pulumi.DependsOn(dependsOn),
+		)
+		if fiErr != nil {
+			return fiErr
+		}
+		if floatingIPInstall != nil {
+			dependsOn = append(dependsOn, floatingIPInstall)
+		}
+
+		// snapshots
+		_, snErr := snapshot.Install(
+			ctx,
+			instance.SSHIPv4,
+			sshKey.PrivateKeyPem,
+			proxy,
+			serverConfig,
+			config.HetznerToken(ctx),
+			pulumi.DependsOn(dependsOn),
+		)
+		if snErr != nil {
+			return snErr
+		}
 
 		// docker
-		dockerInstall, doErr := docker.Install(ctx, instance.SSHIPv4, sshKey.PrivateKeyPem, pulumi.DependsOn(dependsOn))
+		dockerConfigure, doErr := docker.Configure(
+			ctx,
+			instance.SSHIPv4,
+			sshKey.PrivateKeyPem,
+			proxy,
+			pulumi.DependsOn(dependsOn),
+		)
 		if doErr != nil {
 			return doErr
 		}
-		dependsOn = append(dependsOn, dockerInstall)
+		dependsOn = append(dependsOn, dockerConfigure)
 
 		// google cloud
 		serviceAccount, saErr := serviceaccount.Create(ctx, googleConfig, dnsConfig)
@@ -79,6 +201,7 @@ func main() {
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
 			serviceAccount,
 			pulumi.DependsOn(dependsOn),
 		)
@@ -96,6 +219,7 @@ func main() {
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
 			scwApplication,
 			scalewayConfig,
 			pulumi.DependsOn(dependsOn),
@@ -106,11 +230,18 @@ func main() {
 		dependsOn = append(dependsOn, scalewayInstall)
 
 		// traefik
-		traefikInstall, tErr := traefik.Install(
+		traefikData, traefikInstall, tErr := traefik.Install(
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
+			scwApplication,
+			scalewayConfig,
 			dnsConfig,
+			serverConfig,
+			oidcConfig,
+			networkConfig,
+			traefikConfig,
 			pulumi.DependsOn(dependsOn),
 		)
 		if tErr != nil {
@@ -123,10 +254,13 @@ func main() {
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
 			serviceAccount,
 			scwApplication,
 			dnsConfig,
 			googleConfig,
+			vaultConfig,
+			traefikSecrets(traefikData),
 			dependsOn,
 		)
 		if vdErr != nil {
@@ -138,6 +272,7 @@ func main() {
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
 			dnsConfig,
 			oidcConfig,
 			dependsOn,
@@ -151,6 +286,7 @@ func main() {
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
 			instance.Hostname,
 			networkConfig,
 			bgpConfig,
@@ -165,6 +301,7 @@ func main() {
 			ctx,
 			instance.SSHIPv4,
 			sshKey.PrivateKeyPem,
+			proxy,
 			tailscaleConfig,
 			pulumi.DependsOn(dependsOn),
 		)
@@ -176,7 +313,7 @@ func main() {
 		writeOutputFiles(ctx, sshKey, vaultInstanceData)
 
 		// outputs
-		exportPulumiOutputs(ctx, instance, vaultData, vaultInstanceData, wireguardData)
+		exportPulumiOutputs(ctx, instance, dnsData, vaultData, vaultInstanceData, wireguardData)
 
 		return nil
 	})
@@ -218,20 +355,35 @@ func writeOutputFiles(ctx *pulumi.Context, sshKey *tlsProv.PrivateKey, vaultInst
 // exportPulumiOutputs exports the necessary Pulumi outputs.
 // ctx: The Pulumi context.
 // instance: The Hetzner server instance data.
+// dnsData: The DNS resources data.
 // vaultData: The Vault resources data.
 // vaultInstanceData: The Vault instance data output.
 // wireguardData: The WireGuard resources data.
 func exportPulumiOutputs(
 	ctx *pulumi.Context,
 	instance *serverModel.Data,
+	dnsData *dnsModel.Data,
 	vaultData *vaultModel.Data,
 	vaultInstanceData *pulumi.AnyOutput,
 	wireguardData *wireguardModel.Data,
 ) {
-	ctx.Export("server", pulumi.ToMap(map[string]any{
+	serverOutputs := map[string]any{
 		"ipv4": instance.PublicIPv4,
 		"ipv6": instance.PublicIPv6,
-	}))
+	}
+	if instance.FloatingIPs != nil {
+		serverOutputs["floatingIps"] = pulumi.ToMap(map[string]any{
+			"ipv4": instance.FloatingIPs.IPv4,
+			"ipv6": instance.FloatingIPs.IPv6,
+		})
+	}
+	ctx.Export("server", pulumi.ToMap(serverOutputs))
+
+	dsRecords := pulumi.Map{}
+	for zone, records := range dnsData.DSRecords {
+		dsRecords[zone] = records
+	}
+	ctx.Export("dnssec", dsRecords)
 
 	ctx.Export("vault", vaultInstanceData.ApplyT(func(data any) map[string]any {
 		instanceData, _ := data.(*vaultModel.Instance)
@@ -253,6 +405,7 @@ func exportPulumiOutputs(
 					return k.Path
 				}),
 			},
+			"secretsEngines": secretsEnginesOutput(instanceData.SecretsEngines),
 		}
 	}))
 
@@ -260,3 +413,41 @@ func exportPulumiOutputs(
 		"adminPassword": wireguardData.AdminPassword,
 	}))
 }
+
+// secretsEnginesOutput converts the Vault secrets engines into a Pulumi output map.
+// engines: The Vault secrets engines.
+func secretsEnginesOutput(engines *vaultModel.SecretsEngines) map[string]any {
+	kvMounts := pulumi.StringArray{}
+	for _, mount := range engines.KV {
+		kvMounts = append(kvMounts, mount.Path)
+	}
+	out := map[string]any{
+		"kv": kvMounts,
+	}
+	if engines.PKIRoot != nil {
+		out["pki"] = map[string]any{
+			"root":         engines.PKIRoot.Path,
+			"intermediate": engines.PKIIntermediate.Path,
+		}
+	}
+	if engines.Transit != nil {
+		out["transit"] = engines.Transit.Path
+	}
+	if engines.SSH != nil {
+		out["ssh"] = map[string]any{
+			"mount":       engines.SSH.Path,
+			"caPublicKey": engines.SSHCAPublicKey,
+		}
+	}
+	return out
+}
+
+// traefikSecrets returns the Traefik secrets to store in Vault.
+// traefikData: The Traefik data.
+func traefikSecrets(traefikData *traefikModel.Data) map[string]pulumi.StringMapOutput {
+	secrets := make(map[string]pulumi.StringMapOutput, len(traefikData.BasicAuth))
+	for name, basicAuth := range traefikData.BasicAuth {
+		secrets[fmt.Sprintf("traefik-basic-auth-%s", name)] = basicAuth.Passwords
+	}
+	return secrets
+}
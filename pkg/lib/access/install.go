@@ -0,0 +1,138 @@
+package access
+
+import (
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi-tls/sdk/v5/go/tls"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/access"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// Install sets up the deploy user, rotates its SSH key and disables root login on the remote server via SSH.
+// A rotation authorizes the new key with the previous one, and revokes the previous key with the new one.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// serverID: The ID of the server.
+// keys: The SSH keys of the deploy user.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// dependsOn: Pulumi resource option to specify dependencies.
+func Install(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	serverID pulumi.IDOutput,
+	keys *access.Keys,
+	proxy *remote.ProxyConnectionArgs,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) (*remote.Command, error) {
+	publicKey := PublicKey(keys.Current)
+
+	// the connection of the user setup must not change with the rotated key
+	ignoreConnection := pulumi.IgnoreChanges([]string{"connection"})
+
+	createFn, cErr := userScript("./assets/access/create-user.sh.j2", publicKey)
+	if cErr != nil {
+		return nil, cErr
+	}
+	createUser, cuErr := remote.NewCommand(ctx, "remote-command-create-deploy-user", &remote.CommandArgs{
+		Create:     createFn,
+		Triggers:   pulumi.Array{serverID},
+		Connection: install.RootConnection(sshIPv4, keys.Current.PrivateKeyPem, proxy),
+	}, dependsOn, ignoreConnection)
+	if cuErr != nil {
+		return nil, cuErr
+	}
+	last := createUser
+
+	if keys.Previous != nil {
+		authorizeFn, aErr := userScript("./assets/access/authorize.sh.j2", publicKey)
+		if aErr != nil {
+			return nil, aErr
+		}
+		authorize, auErr := remote.NewCommand(ctx, "remote-command-authorize-deploy-key", &remote.CommandArgs{
+			Create:     authorizeFn,
+			Triggers:   pulumi.Array{publicKey},
+			Connection: install.Connection(sshIPv4, keys.Previous.PrivateKeyPem, proxy),
+		}, pulumi.DependsOn([]pulumi.Resource{last}))
+		if auErr != nil {
+			return nil, auErr
+		}
+
+		revokeFn, rErr := userScript("./assets/access/revoke.sh.j2", publicKey)
+		if rErr != nil {
+			return nil, rErr
+		}
+		revoke, reErr := remote.NewCommand(ctx, "remote-command-revoke-deploy-keys", &remote.CommandArgs{
+			Create:     revokeFn,
+			Triggers:   pulumi.Array{publicKey},
+			Connection: install.Connection(sshIPv4, keys.Current.PrivateKeyPem, proxy),
+		}, pulumi.DependsOn([]pulumi.Resource{authorize}))
+		if reErr != nil {
+			return nil, reErr
+		}
+		last = revoke
+	}
+
+	// the sudo rights and the login shell are updated via the deploy user, also on servers set up by earlier versions
+	configureFn, cfErr := userScript("./assets/access/configure-user.sh.j2", publicKey)
+	if cfErr != nil {
+		return nil, cfErr
+	}
+	configureUser, cgErr := remote.NewCommand(ctx, "remote-command-configure-deploy-user", &remote.CommandArgs{
+		Create:     configureFn,
+		Update:     configureFn,
+		Triggers:   pulumi.Array{serverID, configureFn},
+		Connection: install.Connection(sshIPv4, keys.Current.PrivateKeyPem, proxy),
+	}, pulumi.DependsOn([]pulumi.Resource{last}))
+	if cgErr != nil {
+		return nil, cgErr
+	}
+	last = configureUser
+
+	rootLoginConfig, rlErr := file.ReadContents("./assets/access/root-login.conf")
+	if rlErr != nil {
+		return nil, rlErr
+	}
+	disableRootFn, drErr := template.Render("./assets/access/disable-root.sh.j2", map[string]any{
+		"config": rootLoginConfig,
+	})
+	if drErr != nil {
+		return nil, drErr
+	}
+	return remote.NewCommand(ctx, "remote-command-disable-root-login", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(disableRootFn),
+		Update:     pulumi.StringPtr(disableRootFn),
+		Triggers:   pulumi.Array{serverID, pulumi.String(disableRootFn)},
+		Connection: install.Connection(sshIPv4, keys.Current.PrivateKeyPem, proxy),
+	}, pulumi.DependsOn([]pulumi.Resource{last}))
+}
+
+// userScript renders a script managing the deploy user.
+// path: The path of the script template.
+// publicKey: The public key of the deploy user.
+func userScript(path string, publicKey pulumi.StringOutput) (pulumi.StringOutput, error) {
+	sudoers, suErr := file.ReadContents("./assets/access/sudoers")
+	if suErr != nil {
+		return pulumi.StringOutput{}, suErr
+	}
+
+	script, _ := publicKey.ApplyT(func(key string) (string, error) {
+		return template.Render(path, map[string]any{
+			"user":      install.DeployUser,
+			"publicKey": key,
+			"staging":   install.StagingDirectory,
+			"sudoers":   sudoers,
+		})
+	}).(pulumi.StringOutput)
+	return script, nil
+}
+
+// PublicKey returns the OpenSSH public key of a key without the trailing newline.
+// key: The SSH key.
+func PublicKey(key *tls.PrivateKey) pulumi.StringOutput {
+	return key.PublicKeyOpenssh.ApplyT(strings.TrimSpace).(pulumi.StringOutput)
+}
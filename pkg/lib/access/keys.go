@@ -0,0 +1,46 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/lib/tls"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/access"
+	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+)
+
+// CreateKeys creates the SSH key of the configured generation, and the key of the previous generation to rotate from.
+// ctx: The Pulumi context for resource creation.
+// serverConfig: The server configuration.
+func CreateKeys(ctx *pulumi.Context, serverConfig *serverConf.Config) (*access.Keys, error) {
+	generation := defaults.GetOrDefault(serverConfig.SSHKeyGeneration, 0)
+
+	current, cErr := tls.CreateSSHKey(ctx, keyName(generation), 0)
+	if cErr != nil {
+		return nil, cErr
+	}
+	keys := &access.Keys{Current: current}
+
+	if generation > 0 {
+		previous, pErr := tls.CreateSSHKey(ctx, keyName(generation-1), 0)
+		if pErr != nil {
+			return nil, pErr
+		}
+		keys.Previous = previous
+	}
+	return keys, nil
+}
+
+// keyName returns the name of the SSH key of a generation.
+// The first generation keeps the name of the key created before the rotation was introduced.
+// generation: The generation of the key.
+func keyName(generation int) string {
+	name := fmt.Sprintf("core-%s", config.Environment)
+	if generation == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, generation)
+}
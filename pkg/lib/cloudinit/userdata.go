@@ -0,0 +1,128 @@
+package cloudinit
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/docker"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/frr/gre"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// packages are the packages installed by the bootstrap.
+//
+//nolint:gochecknoglobals // global is acceptable here
+var packages = []string{"ca-certificates", "curl", "jq", "nftables"}
+
+// UserData renders the cloud-init user-data performing the base setup of the server:
+// packages, Docker, sysctl, the GRE netplan configuration, the deploy user and the SSH hardening.
+// The files are rendered from the same templates as the remote installers.
+// localIP: The local IP address of the GRE tunnels.
+// publicKey: The public SSH key of the deploy user.
+// bgpConfig: The BGP configuration.
+func UserData(
+	localIP pulumi.StringOutput,
+	publicKey pulumi.StringOutput,
+	bgpConfig *bgp.Config,
+) (pulumi.StringOutput, error) {
+	dockerScript, dErr := docker.Script()
+	if dErr != nil {
+		return pulumi.StringOutput{}, dErr
+	}
+	dockerConfigureScript, dcErr := docker.ConfigureScript()
+	if dcErr != nil {
+		return pulumi.StringOutput{}, dcErr
+	}
+	sshdConfig, sErr := file.ReadContents("./assets/cloudinit/sshd_hardening.conf")
+	if sErr != nil {
+		return pulumi.StringOutput{}, sErr
+	}
+	sysctlConfig, scErr := file.ReadContents("./assets/cloudinit/sysctl_forwarding.conf")
+	if scErr != nil {
+		return pulumi.StringOutput{}, scErr
+	}
+	sudoers, suErr := file.ReadContents("./assets/access/sudoers")
+	if suErr != nil {
+		return pulumi.StringOutput{}, suErr
+	}
+	grePrepare, gpErr := file.ReadContents("./assets/frr/gre/prepare.sh")
+	if gpErr != nil {
+		return pulumi.StringOutput{}, gpErr
+	}
+
+	userData, _ := pulumi.All(localIP, publicKey).ApplyT(func(args []any) (string, error) {
+		localIP, _ := args[0].(string)
+		publicKey, _ := args[1].(string)
+
+		files := []map[string]string{
+			writeFile(fmt.Sprintf("/etc/sudoers.d/%s", install.DeployUser), "0440", sudoers),
+			writeFile("/etc/ssh/sshd_config.d/99-hardening.conf", "0644", sshdConfig),
+			writeFile("/etc/sysctl.d/99-forwarding.conf", "0644", sysctlConfig),
+			writeFile("/root/bootstrap/docker.sh", "0700", dockerScript),
+			writeFile("/root/bootstrap/docker-configure.sh", "0700", dockerConfigureScript),
+		}
+		commands := []string{
+			fmt.Sprintf("install -d -o %s -g %s -m 0700 %s", install.DeployUser, install.DeployUser, install.StagingDirectory),
+			"sysctl --system",
+			"sh /root/bootstrap/docker.sh",
+			"sh /root/bootstrap/docker-configure.sh",
+			"systemctl restart ssh",
+		}
+
+		var tunnels bool
+		for _, name := range slices.Sorted(maps.Keys(bgpConfig.Neighbors)) {
+			neighbor := bgpConfig.Neighbors[name]
+			if neighbor.GRE == nil {
+				continue
+			}
+			tunnels = true
+			files = append(files, writeFile(
+				fmt.Sprintf("/etc/netplan/%s.yaml", *neighbor.InterfaceName),
+				"0600",
+				gre.Netplan(localIP, neighbor),
+			))
+		}
+		if tunnels {
+			files = append(files,
+				writeFile("/root/bootstrap/gre.sh", "0700", grePrepare),
+				writeFile("/etc/modules-load.d/gre.conf", "0644", "ip_gre\n"),
+			)
+			commands = append(commands, "sh /root/bootstrap/gre.sh", "modprobe ip_gre", "netplan apply")
+		}
+
+		quoted := make([]string, 0, len(commands))
+		for _, command := range commands {
+			quoted = append(quoted, strconv.Quote(command))
+		}
+		return template.Render("./assets/cloudinit/user-data.yml.j2", map[string]any{
+			"packages":  packages,
+			"user":      install.DeployUser,
+			"publicKey": strconv.Quote(strings.TrimSpace(publicKey)),
+			"files":     files,
+			"commands":  quoted,
+		})
+	}).(pulumi.StringOutput)
+
+	return userData, nil
+}
+
+// writeFile returns a cloud-init file with base64 encoded content.
+// path: The path of the file.
+// permissions: The octal permissions of the file.
+// content: The content of the file.
+func writeFile(path string, permissions string, content string) map[string]string {
+	return map[string]string{
+		"path":        path,
+		"permissions": permissions,
+		"content":     base64.StdEncoding.EncodeToString([]byte(content)),
+	}
+}
@@ -0,0 +1,39 @@
+package cloudinit
+
+import (
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// Wait waits for the cloud-init bootstrap to finish on the remote server via SSH.
+// The wait is repeated whenever the server is rebuilt; root login is only available until the bootstrap finished,
+// hence the connection must not change afterwards (e.g., with a rotated key or a bastion).
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// serverID: The ID of the server.
+// dependsOn: Pulumi resource option to specify dependencies.
+func Wait(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	serverID pulumi.IDOutput,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) (*remote.Command, error) {
+	conn := install.RootConnection(sshIPv4, privateKeyPem, proxy)
+
+	waitFn, wErr := file.ReadContents("./assets/cloudinit/wait.sh")
+	if wErr != nil {
+		return nil, wErr
+	}
+	return remote.NewCommand(ctx, "remote-command-wait-cloud-init", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(waitFn),
+		Triggers:   pulumi.Array{serverID},
+		Connection: conn,
+	}, dependsOn, pulumi.IgnoreChanges([]string{"connection"}))
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/scaleway"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/tailscale"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
 )
 
 //nolint:gochecknoglobals // global configuration is acceptable here
@@ -38,7 +40,7 @@ var (
 // ctx: The Pulumi context.
 func LoadConfig(
 	ctx *pulumi.Context,
-) (*google.Config, *scaleway.Config, *server.Config, *network.Config, *oidc.Config, *dns.Config, *bgp.Config, *tailscale.Config, error) {
+) (*google.Config, *scaleway.Config, *server.Config, *network.Config, *oidc.Config, *dns.Config, *bgp.Config, *tailscale.Config, *vault.Config, *traefik.Config, error) {
 	Environment = ctx.Stack()
 
 	cfg := config.New(ctx, "")
@@ -72,7 +74,39 @@ func LoadConfig(
 	var tailscaleConfig tailscale.Config
 	cfg.RequireObject("tailscale", &tailscaleConfig)
 
-	return &googleConfig, &scalewayConfig, &serverConfig, &networkConfig, &oidcConfig, &dnsConfig, &bgpConfig, &tailscaleConfig, nil
+	var vaultConfig vault.Config
+	if vErr := cfg.GetObject("vault", &vaultConfig); vErr != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, vErr
+	}
+
+	var traefikConfig traefik.Config
+	if tErr := cfg.GetObject("traefik", &traefikConfig); tErr != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, tErr
+	}
+
+	return &googleConfig, &scalewayConfig, &serverConfig, &networkConfig, &oidcConfig, &dnsConfig, &bgpConfig, &tailscaleConfig, &vaultConfig, &traefikConfig, nil
+}
+
+// BastionPrivateKey returns the private key in PEM format to authenticate at the bastion with, if configured.
+// The key is read from the secret configuration value `bastionPrivateKey`.
+// ctx: The Pulumi context.
+func BastionPrivateKey(ctx *pulumi.Context) *pulumi.StringOutput {
+	privateKey, err := config.New(ctx, "").TrySecret("bastionPrivateKey")
+	if err != nil {
+		return nil
+	}
+	return &privateKey
+}
+
+// HetznerToken returns the Hetzner Cloud API token used on the server, if configured.
+// The token is read from the secret configuration value `hcloudServerToken`.
+// ctx: The Pulumi context.
+func HetznerToken(ctx *pulumi.Context) *pulumi.StringOutput {
+	token, err := config.New(ctx, "").TrySecret("hcloudServerToken")
+	if err != nil {
+		return nil
+	}
+	return &token
 }
 
 // CommonLabels returns a map of common labels to be used across resources.
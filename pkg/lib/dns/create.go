@@ -0,0 +1,184 @@
+package dns
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	googleDns "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/google/dns"
+	scalewayDns "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/scaleway/dns"
+	dnsConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/scaleway"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+)
+
+const (
+	// providerGoogle is the Google Cloud DNS provider.
+	providerGoogle = "google"
+	// providerScaleway is the Scaleway Domains provider.
+	providerScaleway = "scaleway"
+)
+
+const (
+	// targetPublic points records to the floating IP addresses of the server, or its public IP addresses.
+	targetPublic = "public"
+	// targetPrivate points records to the private IP address of the server.
+	targetPrivate = "private"
+)
+
+// defaultTTL is the default time to live of records in seconds.
+const defaultTTL = 300
+
+// Create creates the DNS records of all entries in the provided DNS configuration at the provider of their zone,
+// and the CAA records and DNSSEC configuration of the configured zones.
+// ctx: The Pulumi context for resource creation.
+// dnsConfig: The DNS configuration containing domain and record details.
+// scalewayConfig: The Scaleway configuration containing the DNS project.
+// instance: The server the records point to.
+// caa: The certificate authorities allowed to issue certificates.
+func Create(
+	ctx *pulumi.Context,
+	dnsConfig *dnsConf.Config,
+	scalewayConfig *scaleway.Config,
+	instance *server.Data,
+	caa *dns.CAA,
+) (*dns.Data, error) {
+	var resources []pulumi.Resource
+
+	for _, name := range slices.Sorted(maps.Keys(dnsConfig.Entries)) {
+		entry := dnsConfig.Entries[name]
+		if entry.Domain == nil || entry.ZoneID == nil {
+			return nil, fmt.Errorf("%w: %s: missing domain or zone", ErrInvalidEntry, name)
+		}
+
+		records, rErr := entryRecords(name, &entry, instance)
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		for _, record := range records {
+			created, err := createRecord(ctx, record, dnsConfig, scalewayConfig)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, created...)
+		}
+	}
+
+	zoneResources, dsRecords, zErr := createZones(ctx, dnsConfig, scalewayConfig, caa)
+	if zErr != nil {
+		return nil, zErr
+	}
+
+	return &dns.Data{
+		Resources: append(resources, zoneResources...),
+		DSRecords: dsRecords,
+	}, nil
+}
+
+// createRecord creates a record at the provider of its zone.
+// ctx: The Pulumi context for resource creation.
+// record: The record to create.
+// dnsConfig: The DNS configuration.
+// scalewayConfig: The Scaleway configuration containing the DNS project.
+func createRecord(
+	ctx *pulumi.Context,
+	record *dns.Record,
+	dnsConfig *dnsConf.Config,
+	scalewayConfig *scaleway.Config,
+) ([]pulumi.Resource, error) {
+	provider := zoneProvider(dnsConfig, record.Zone)
+	switch provider {
+	case providerGoogle:
+		return googleDns.CreateRecord(ctx, record, dnsConfig.Project)
+	case providerScaleway:
+		if !inZone(record.Domain, record.Zone) {
+			return nil, fmt.Errorf("%w: %s: domain is not within zone %q", ErrInvalidEntry, record.Name, record.Zone)
+		}
+		return scalewayDns.CreateRecord(ctx, record, scalewayConfig.DNSProject)
+	default:
+		return nil, fmt.Errorf("%w: %s: unknown provider %q", ErrInvalidZone, record.Zone, provider)
+	}
+}
+
+// zoneProvider returns the DNS provider of a zone.
+// dnsConfig: The DNS configuration.
+// zoneID: The zone identifier.
+func zoneProvider(dnsConfig *dnsConf.Config, zoneID string) string {
+	zone, ok := dnsConfig.Zones[zoneID]
+	if !ok || zone == nil {
+		return providerGoogle
+	}
+	return defaults.GetOrDefault(zone.Provider, providerGoogle)
+}
+
+// entryRecords returns the record sets of a DNS entry.
+// name: The name of the entry.
+// entry: The DNS entry.
+// instance: The server the records point to.
+func entryRecords(name string, entry *dnsConf.EntryConfig, instance *server.Data) ([]*dns.Record, error) {
+	record := func(recordType string, values ...pulumi.StringInput) *dns.Record {
+		return &dns.Record{
+			Name:   name,
+			Domain: *entry.Domain,
+			Zone:   *entry.ZoneID,
+			Type:   recordType,
+			TTL:    defaults.GetOrDefault(entry.TTL, defaultTTL),
+			Values: values,
+		}
+	}
+
+	if len(entry.Values) > 0 {
+		if entry.Type == nil {
+			return nil, fmt.Errorf("%w: %s: literal values require a record type", ErrInvalidEntry, name)
+		}
+		values := make([]pulumi.StringInput, 0, len(entry.Values))
+		for _, value := range entry.Values {
+			values = append(values, pulumi.String(value))
+		}
+		return []*dns.Record{record(*entry.Type, values...)}, nil
+	}
+
+	target := defaults.GetOrDefault(entry.Target, targetPublic)
+	recordType := defaults.GetOrDefault(entry.Type, "")
+	if recordType != "" && recordType != "A" && recordType != "AAAA" {
+		return nil, fmt.Errorf("%w: %s: target %q requires an A or AAAA record", ErrInvalidEntry, name, target)
+	}
+
+	switch target {
+	case targetPublic:
+		ipv4, ipv6 := instance.PublicIPv4, instance.PublicIPv6
+		if instance.FloatingIPs != nil {
+			ipv4, ipv6 = instance.FloatingIPs.IPv4, instance.FloatingIPs.IPv6
+		}
+		var records []*dns.Record
+		if recordType != "AAAA" {
+			records = append(records, record("A", ipv4))
+		}
+		if recordType != "A" {
+			records = append(records, record("AAAA", ipv6))
+		}
+		return records, nil
+	case targetPrivate:
+		if recordType == "AAAA" {
+			return nil, fmt.Errorf("%w: %s: the private target has no IPv6 address", ErrInvalidEntry, name)
+		}
+		return []*dns.Record{record("A", instance.PrivateIPv4)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s: unknown target %q", ErrInvalidEntry, name, target)
+	}
+}
+
+// inZone returns whether the domain is the zone or one of its subdomains.
+// domain: The domain name.
+// zone: The zone domain.
+func inZone(domain string, zone string) bool {
+	domain = strings.TrimSuffix(domain, ".")
+	zone = strings.TrimSuffix(zone, ".")
+	return domain == zone || strings.HasSuffix(domain, fmt.Sprintf(".%s", zone))
+}
@@ -0,0 +1,10 @@
+package dns
+
+import "errors"
+
+var (
+	// ErrInvalidEntry is returned if a DNS entry configuration is invalid.
+	ErrInvalidEntry = errors.New("dns: invalid entry")
+	// ErrInvalidZone is returned if a DNS zone configuration is invalid.
+	ErrInvalidZone = errors.New("dns: invalid zone")
+)
@@ -0,0 +1,98 @@
+package dns
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	googleDns "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/google/dns"
+	dnsConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/scaleway"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/dns"
+)
+
+// createZones creates the CAA records and enables DNSSEC of the configured zones.
+// ctx: The Pulumi context for resource creation.
+// dnsConfig: The DNS configuration containing the zones.
+// scalewayConfig: The Scaleway configuration containing the DNS project.
+// caa: The certificate authorities allowed to issue certificates.
+func createZones(
+	ctx *pulumi.Context,
+	dnsConfig *dnsConf.Config,
+	scalewayConfig *scaleway.Config,
+	caa *dns.CAA,
+) ([]pulumi.Resource, map[string]pulumi.StringArrayOutput, error) {
+	var resources []pulumi.Resource
+	dsRecords := map[string]pulumi.StringArrayOutput{}
+
+	for _, zoneID := range slices.Sorted(maps.Keys(dnsConfig.Zones)) {
+		zone := dnsConfig.Zones[zoneID]
+		if zone == nil {
+			continue
+		}
+		domain := defaults.GetOrDefault(zone.Domain, zoneID)
+
+		if zone.CAA != nil {
+			created, err := createRecord(ctx, &dns.Record{
+				Name:   fmt.Sprintf("zone-%s", zoneID),
+				Domain: domain,
+				Zone:   zoneID,
+				Type:   "CAA",
+				TTL:    defaultTTL,
+				Values: caaValues(zone.CAA, caa),
+			}, dnsConfig, scalewayConfig)
+			if err != nil {
+				return nil, nil, err
+			}
+			resources = append(resources, created...)
+		}
+
+		if defaults.GetOrDefault(zone.DNSSEC, false) {
+			provider := zoneProvider(dnsConfig, zoneID)
+			if provider != providerGoogle {
+				return nil, nil, fmt.Errorf("%w: %s: DNSSEC is not supported by provider %q", ErrInvalidZone, zoneID,
+					provider)
+			}
+
+			managedZone, ds, err := googleDns.EnableDNSSEC(ctx, zoneID, domain, dnsConfig.Project)
+			if err != nil {
+				return nil, nil, err
+			}
+			resources = append(resources, managedZone)
+			dsRecords[zoneID] = ds
+		}
+	}
+
+	return resources, dsRecords, nil
+}
+
+// caaValues returns the values of the CAA record of a zone.
+// Additional issuers are only allowed to issue non-wildcard certificates; without wildcard issuers,
+// wildcard certificates are forbidden.
+// caaConfig: The CAA configuration of the zone.
+// caa: The certificate authorities allowed to issue certificates.
+func caaValues(caaConfig *dnsConf.CAAConfig, caa *dns.CAA) []pulumi.StringInput {
+	var values []pulumi.StringInput
+
+	issuers := slices.Concat(caa.Issue, caaConfig.Issuers)
+	slices.Sort(issuers)
+	for _, issuer := range slices.Compact(issuers) {
+		values = append(values, pulumi.String(fmt.Sprintf("0 issue %s", strconv.Quote(issuer))))
+	}
+
+	if len(caa.IssueWild) == 0 {
+		values = append(values, pulumi.String(fmt.Sprintf("0 issuewild %s", strconv.Quote(";"))))
+	}
+	for _, issuer := range caa.IssueWild {
+		values = append(values, pulumi.String(fmt.Sprintf("0 issuewild %s", strconv.Quote(issuer))))
+	}
+
+	if caaConfig.IODEF != nil {
+		values = append(values, pulumi.String(fmt.Sprintf("0 iodef %s", strconv.Quote(*caaConfig.IODEF))))
+	}
+	return values
+}
@@ -5,37 +5,49 @@ import (
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
 	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
 )
 
-// Install Docker on the remote server via SSH.
+// Configure configures the Docker daemon installed by the cloud-init bootstrap on the remote server via SSH.
+// The daemon is restarted whenever its configuration changes.
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // dependsOn: Pulumi resource option to specify dependencies.
-func Install(
+func Configure(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	configureFn, cfErr := ConfigureScript()
+	if cfErr != nil {
+		return nil, cfErr
 	}
+	return remote.NewCommand(ctx, "remote-command-configure-docker", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(configureFn),
+		Triggers:   pulumi.Array{pulumi.String(configureFn)},
+		Connection: conn,
+	}, dependsOn)
+}
 
+// Script returns the script installing Docker, run by the cloud-init bootstrap.
+func Script() (string, error) {
+	return file.ReadContents("./assets/docker/install.sh")
+}
+
+// ConfigureScript returns the script configuring and starting the Docker daemon, shared with the cloud-init bootstrap.
+func ConfigureScript() (string, error) {
 	daemonJSON, dErr := file.ReadContents("./assets/docker/daemon.json")
 	if dErr != nil {
-		return nil, dErr
+		return "", dErr
 	}
-	createFn, cfErr := template.Render("./assets/docker/install.sh", map[string]any{
+	return template.Render("./assets/docker/configure.sh.j2", map[string]any{
 		"daemonJson": daemonJSON,
 	})
-	if cfErr != nil {
-		return nil, cfErr
-	}
-	return remote.NewCommand(ctx, "remote-command-install-docker", &remote.CommandArgs{
-		Create:     pulumi.StringPtr(createFn),
-		Connection: conn,
-	}, dependsOn)
 }
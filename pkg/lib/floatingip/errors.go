@@ -0,0 +1,6 @@
+package floatingip
+
+import "errors"
+
+// ErrInvalidFailover is returned if the failover of the floating IPs is configured without a token or peers.
+var ErrInvalidFailover = errors.New("floatingip: invalid failover")
@@ -0,0 +1,121 @@
+package floatingip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+const (
+	// defaultPriority is the default VRRP priority.
+	defaultPriority = 100
+	// defaultRouterID is the default VRRP virtual router ID.
+	defaultRouterID = 51
+)
+
+// defaultServices are the systemd services checked for the health of the server by default.
+//
+//nolint:gochecknoglobals // global is acceptable here
+var defaultServices = []string{"traefik", "wireguard"}
+
+// Install configures the floating IPs on the remote server via SSH.
+// If a failover is configured, keepalived reassigns the floating IPs to the healthy core server
+// with the highest priority. Nothing is installed if no floating IPs are configured.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// floatingIPs: The floating IPs of the server.
+// serverConfig: The server configuration.
+// token: The Hetzner Cloud API token to reassign the floating IPs with (required for the failover).
+// dependsOn: Pulumi resource option to specify dependencies.
+func Install(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	floatingIPs *server.FloatingIPs,
+	serverConfig *serverConf.Config,
+	token *pulumi.StringOutput,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) (*remote.Command, error) {
+	if floatingIPs == nil {
+		return nil, nil
+	}
+
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	configureFn, _ := pulumi.All(floatingIPs.IPv4, floatingIPs.IPv6).ApplyT(func(args []any) (string, error) {
+		netplan, nErr := template.Render("./assets/floatingip/netplan.yml.j2", map[string]any{
+			"ipv4": args[0],
+			"ipv6": args[1],
+		})
+		if nErr != nil {
+			return "", nErr
+		}
+		return template.Render("./assets/floatingip/configure.sh.j2", map[string]any{
+			"netplan": netplan,
+		})
+	}).(pulumi.StringOutput)
+	configure, cErr := remote.NewCommand(ctx, "remote-command-configure-floating-ips", &remote.CommandArgs{
+		Create:     configureFn,
+		Update:     configureFn,
+		Triggers:   pulumi.Array{configureFn},
+		Connection: conn,
+	}, dependsOn)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	failoverConfig := serverConfig.FloatingIPs.Failover
+	if failoverConfig == nil {
+		return configure, nil
+	}
+	if token == nil {
+		return nil, fmt.Errorf("%w: missing token", ErrInvalidFailover)
+	}
+	if len(failoverConfig.Peers) == 0 {
+		return nil, fmt.Errorf("%w: missing peers", ErrInvalidFailover)
+	}
+
+	opts := []pulumi.ResourceOption{dependsOn, pulumi.DependsOn([]pulumi.Resource{configure})}
+
+	opts, prepErr := install.Prepare(ctx, "floatingip", conn, opts...)
+	if prepErr != nil {
+		return nil, prepErr
+	}
+
+	tokenCopy := install.CopySecretToRemote(ctx, "floatingip-token", *token, "/opt/floatingip/token", conn, opts...)
+	opts = append(opts, install.CollectResourceOptions([]pulumi.Output{tokenCopy})...)
+
+	services := failoverConfig.Services
+	if len(services) == 0 {
+		services = defaultServices
+	}
+	failoverFn, _ := pulumi.All(floatingIPs.IPv4ID, floatingIPs.IPv6ID).ApplyT(func(args []any) (string, error) {
+		return template.Render("./assets/floatingip/failover.sh.j2", map[string]any{
+			"services":  strings.Join(services, " "),
+			"ipv4Id":    args[0],
+			"ipv6Id":    args[1],
+			"privateIp": *serverConfig.IPv4,
+			"peers":     failoverConfig.Peers,
+			"priority":  defaults.GetOrDefault(failoverConfig.Priority, defaultPriority),
+			"routerId":  defaults.GetOrDefault(failoverConfig.RouterID, defaultRouterID),
+		})
+	}).(pulumi.StringOutput)
+
+	return remote.NewCommand(ctx, "remote-command-install-floating-ip-failover", &remote.CommandArgs{
+		Create:     failoverFn,
+		Update:     failoverFn,
+		Triggers:   pulumi.Array{failoverFn},
+		Connection: conn,
+	}, opts...)
+}
@@ -20,20 +20,18 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // bgpConfig: The BGP configuration details.
 // dependsOn: Pulumi resource option to specify dependencies.
 func installer(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	bgpConfig *bgp.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (pulumi.Resource, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -85,22 +83,7 @@ func createConfig(
 	neighbor *bgp.NeighborConfig,
 ) pulumi.StringOutput {
 	netplanConfig, _ := localIP.ApplyT(func(localIP string) string {
-		netplanData := struct {
-			Name     string
-			LocalIP  string
-			RemoteIP string
-			TunnelIP string
-			Type     string
-		}{
-			Name:     *neighbor.InterfaceName,
-			LocalIP:  localIP,
-			RemoteIP: *neighbor.GRE.RemoteIP,
-			TunnelIP: *neighbor.GRE.TunnelIP,
-			Type:     defaults.GetOrDefault(neighbor.GRE.Type, "gre"),
-		}
-
-		config, _ := template.Render("./assets/frr/gre/config/netplan.yml.j2", netplanData)
-		return config
+		return Netplan(localIP, neighbor)
 	}).(pulumi.StringOutput)
 
 	netplanConfigHash, _ := file.WritePulumi(fmt.Sprintf("./outputs/gre_netplan_%s.yaml", *neighbor.InterfaceName), netplanConfig).
@@ -112,6 +95,28 @@ func createConfig(
 	return netplanConfigHash
 }
 
+// Netplan renders the GRE netplan configuration of a BGP neighbor, shared with the cloud-init bootstrap.
+// localIP: The local IP address to be used in the GRE configuration.
+// neighbor: The BGP neighbor for which the GRE configuration is being created.
+func Netplan(localIP string, neighbor *bgp.NeighborConfig) string {
+	netplanData := struct {
+		Name     string
+		LocalIP  string
+		RemoteIP string
+		TunnelIP string
+		Type     string
+	}{
+		Name:     *neighbor.InterfaceName,
+		LocalIP:  localIP,
+		RemoteIP: *neighbor.GRE.RemoteIP,
+		TunnelIP: *neighbor.GRE.TunnelIP,
+		Type:     defaults.GetOrDefault(neighbor.GRE.Type, "gre"),
+	}
+
+	config, _ := template.Render("./assets/frr/gre/config/netplan.yml.j2", netplanData)
+	return config
+}
+
 // writeToRemote uploads the GRE netplan config file to the remote server.
 // ctx: Pulumi context.
 // bgpConfig: The BGP configuration details.
@@ -125,7 +130,7 @@ func writeToRemote(
 	opts ...pulumi.ResourceOption,
 ) pulumi.Output {
 	netplanConfigCopy := hash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			fmt.Sprintf("remote-copy-gre-netplan-%s", *interfaceName),
 			&remote.CopyToRemoteArgs{
@@ -134,6 +139,7 @@ func writeToRemote(
 				Triggers:   pulumi.Array{hash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
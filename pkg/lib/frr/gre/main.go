@@ -1,6 +1,7 @@
 package gre
 
 import (
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
@@ -13,6 +14,7 @@ import (
 func Install(ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	bgpConfig *bgp.Config,
 	dependsOn []pulumi.Resource,
 ) (pulumi.Resource, error) {
@@ -20,6 +22,7 @@ func Install(ctx *pulumi.Context,
 		ctx,
 		sshIPv4,
 		privateKeyPem,
+		proxy,
 		bgpConfig,
 		pulumi.DependsOn(dependsOn),
 	)
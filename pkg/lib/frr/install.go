@@ -15,6 +15,7 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // frrData: The FRR configuration data.
 // bgpConfig: The BGP configuration details.
 // dependsOn: Pulumi resource option to specify dependencies.
@@ -22,15 +23,12 @@ func installer(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	frrData *frr.Data,
 	bgpConfig *bgp.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -43,7 +41,7 @@ func installer(
 	if dcErr != nil {
 		return nil, dcErr
 	}
-	dockerComposeCopy, ccErr := remote.NewCopyToRemote(
+	dockerComposeCopy, ccErr := install.CopyToRemote(
 		ctx,
 		"remote-copy-frr-docker-compose",
 		&remote.CopyToRemoteArgs{
@@ -52,6 +50,7 @@ func installer(
 			Triggers:   pulumi.Array{pulumi.String(*dockerComposeHash)},
 			Connection: conn,
 		},
+		0o644,
 		opts...)
 	if ccErr != nil {
 		return nil, ccErr
@@ -119,12 +118,12 @@ func createConfigs(
 			return *hash
 		}).(pulumi.StringOutput)
 	frrConfigCopy := frrConfigHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(ctx, "remote-copy-frr-config", &remote.CopyToRemoteArgs{
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-frr-config", &remote.CopyToRemoteArgs{
 			Source:     pulumi.NewFileAsset("./outputs/frr_frr.conf"),
 			RemotePath: pulumi.String("/opt/frr/config/frr.conf"),
 			Triggers:   pulumi.Array{frrConfigHash},
 			Connection: conn,
-		}, opts...)
+		}, 0o644, opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
@@ -133,12 +132,12 @@ func createConfigs(
 		return nil, nil, vtErr
 	}
 	vtyshConfigCopy := pulumi.String(*vtyshConfigHash).ToStringOutput().ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(ctx, "remote-copy-frr-vtysh", &remote.CopyToRemoteArgs{
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-frr-vtysh", &remote.CopyToRemoteArgs{
 			Source:     pulumi.NewFileAsset("./assets/frr/config/vtysh.conf"),
 			RemotePath: pulumi.String("/opt/frr/config/vtysh.conf"),
 			Triggers:   pulumi.Array{pulumi.String(*vtyshConfigHash)},
 			Connection: conn,
-		}, opts...)
+		}, 0o644, opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
@@ -147,12 +146,12 @@ func createConfigs(
 		return nil, nil, dhErr
 	}
 	daemonsCopy := pulumi.String(*daemonsHash).ToStringOutput().ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(ctx, "remote-copy-frr-daemons", &remote.CopyToRemoteArgs{
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-frr-daemons", &remote.CopyToRemoteArgs{
 			Source:     pulumi.NewFileAsset("./assets/frr/config/daemons"),
 			RemotePath: pulumi.String("/opt/frr/config/daemons"),
 			Triggers:   pulumi.Array{pulumi.String(*daemonsHash)},
 			Connection: conn,
-		}, opts...)
+		}, 0o644, opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
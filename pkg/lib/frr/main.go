@@ -19,6 +19,7 @@ import (
 func Install(ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	hostname pulumi.StringOutput,
 	networkConfig *network.Config,
 	bgpConfig *bgp.Config,
@@ -29,7 +30,7 @@ func Install(ctx *pulumi.Context,
 		return nil, nil, frrErr
 	}
 
-	greInstall, greErr := gre.Install(ctx, sshIPv4, privateKeyPem, bgpConfig, dependsOn)
+	greInstall, greErr := gre.Install(ctx, sshIPv4, privateKeyPem, proxy, bgpConfig, dependsOn)
 	if greErr != nil {
 		return nil, nil, greErr
 	}
@@ -39,6 +40,7 @@ func Install(ctx *pulumi.Context,
 		ctx,
 		sshIPv4,
 		privateKeyPem,
+		proxy,
 		frrData,
 		bgpConfig,
 		pulumi.DependsOn(pulumiResources),
@@ -14,20 +14,18 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // serviceAccount: The Google service account to use for authentication.
 // dependsOn: Pulumi resource option to specify dependencies.
 func Install(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	serviceAccount *serviceaccount.User,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -46,7 +44,7 @@ func Install(
 			return *hash
 		})
 	gcpCredentialsCopy := gcpCredentialsHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			"remote-copy-gcloud-service-account",
 			&remote.CopyToRemoteArgs{
@@ -55,6 +53,7 @@ func Install(
 				Triggers:   pulumi.Array{gcpCredentialsHash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
@@ -1,28 +1,47 @@
 package dns
 
 import (
+	"fmt"
+	"strings"
+
+	gcpDns "github.com/pulumi/pulumi-gcp/sdk/v9/go/gcp/dns"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
-	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/vault"
-	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/wireguard"
-	dnsConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/dns"
 )
 
-// Create creates DNS records for the given IP addresses based on the provided DNS configuration.
+// CreateRecord creates a record set in Google Cloud DNS.
+// Record sets of earlier versions were created by the shared library, named by domain; they are adopted via an alias.
 // ctx: The Pulumi context for resource creation.
-// dnsConfig: The DNS configuration containing domain and record details.
-// ipv4: The public IPv4 address to create DNS records for.
-// ipv6: The public IPv6 address to create DNS records for.
-func Create(
-	ctx *pulumi.Context,
-	dnsConfig *dnsConf.Config,
-	ipv4 pulumi.StringOutput,
-	ipv6 pulumi.StringOutput,
-) []pulumi.Resource {
-	var resources []pulumi.Resource
-
-	resources = append(resources, vault.CreateDNSRecords(ctx, dnsConfig, ipv4, ipv6)...)
-	resources = append(resources, wireguard.CreateDNSRecords(ctx, dnsConfig, ipv4, ipv6)...)
+// record: The record to create.
+// project: The Google Cloud project of the managed zone.
+func CreateRecord(ctx *pulumi.Context, record *dns.Record, project *string) ([]pulumi.Resource, error) {
+	recordSet, err := gcpDns.NewRecordSet(
+		ctx,
+		fmt.Sprintf("gcp-dns-record-%s-%s", record.Name, strings.ToLower(record.Type)),
+		&gcpDns.RecordSetArgs{
+			Name:        pulumi.String(fqdn(record.Domain)),
+			ManagedZone: pulumi.String(record.Zone),
+			Type:        pulumi.String(record.Type),
+			Ttl:         pulumi.Int(record.TTL),
+			Rrdatas:     pulumi.StringArray(record.Values),
+			Project:     pulumi.StringPtrFromPtr(project),
+		},
+		pulumi.Aliases([]pulumi.Alias{
+			{Name: pulumi.Sprintf("gcp-dns-record-%s-%s", record.Domain, strings.ToLower(record.Type))},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return []pulumi.Resource{recordSet}, nil
+}
 
-	return resources
+// fqdn returns the fully qualified domain name with a trailing dot.
+// domain: The domain name.
+func fqdn(domain string) string {
+	if strings.HasSuffix(domain, ".") {
+		return domain
+	}
+	return fmt.Sprintf("%s.", domain)
 }
@@ -0,0 +1,51 @@
+package dns
+
+import (
+	"fmt"
+
+	gcpDns "github.com/pulumi/pulumi-gcp/sdk/v9/go/gcp/dns"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// EnableDNSSEC enables DNSSEC on an existing managed zone and returns its DS records.
+// The zone must be imported into the stack beforehand (see README), as an import with DNSSEC enabled
+// does not match the zone; it is retained when the stack is destroyed.
+// ctx: The Pulumi context for resource creation.
+// zoneID: The name of the managed zone.
+// domain: The domain of the managed zone.
+// project: The Google Cloud project of the managed zone.
+func EnableDNSSEC(
+	ctx *pulumi.Context,
+	zoneID string,
+	domain string,
+	project *string,
+) (pulumi.Resource, pulumi.StringArrayOutput, error) {
+	zone, err := gcpDns.NewManagedZone(ctx, fmt.Sprintf("gcp-dns-zone-%s", zoneID), &gcpDns.ManagedZoneArgs{
+		Name:    pulumi.String(zoneID),
+		DnsName: pulumi.String(fqdn(domain)),
+		Project: pulumi.StringPtrFromPtr(project),
+		DnssecConfig: &gcpDns.ManagedZoneDnssecConfigArgs{
+			State: pulumi.String("on"),
+		},
+	},
+		pulumi.RetainOnDelete(true),
+		pulumi.IgnoreChanges([]string{"description", "labels", "visibility", "forceDestroy", "cloudLoggingConfig"}),
+	)
+	if err != nil {
+		return nil, pulumi.StringArrayOutput{}, err
+	}
+
+	keys := gcpDns.GetKeysOutput(ctx, gcpDns.GetKeysOutputArgs{
+		ManagedZone: zone.Name,
+		Project:     pulumi.StringPtrFromPtr(project),
+	})
+	dsRecords, _ := keys.KeySigningKeys().ApplyT(func(ksks []gcpDns.GetKeysKeySigningKey) []string {
+		records := make([]string, 0, len(ksks))
+		for _, ksk := range ksks {
+			records = append(records, ksk.DsRecord)
+		}
+		return records
+	}).(pulumi.StringArrayOutput)
+
+	return zone, dsRecords, nil
+}
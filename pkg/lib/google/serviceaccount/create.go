@@ -28,27 +28,10 @@ func Create(ctx *pulumi.Context, googleConfig *google.Config, dnsConfig *dns.Con
 	}
 
 	iam.ServiceAccount.Email.ApplyT(func(email string) error {
-		keyringID := fmt.Sprintf(
-			"%s/%s/%s",
-			*googleConfig.Project,
-			*googleConfig.EncryptionKey.Location,
-			*googleConfig.EncryptionKey.KeyringID,
-		)
-		_, _ = kmsIam.CreateKeyringBinding(ctx, &kmsIam.KeyringBindingOptions{
-			KeyRingID: keyringID,
-			Member:    fmt.Sprintf("serviceAccount:%s", email),
-			Role:      "roles/cloudkms.cryptoKeyEncrypterDecrypter",
-		})
-		_, _ = kmsIam.CreateKeyringBinding(ctx, &kmsIam.KeyringBindingOptions{
-			KeyRingID: keyringID,
-			Member:    fmt.Sprintf("serviceAccount:%s", email),
-			Role:      "roles/cloudkms.signerVerifier",
-		})
-		_, _ = kmsIam.CreateKeyringBinding(ctx, &kmsIam.KeyringBindingOptions{
-			KeyRingID: keyringID,
-			Member:    fmt.Sprintf("serviceAccount:%s", email),
-			Role:      "roles/cloudkms.viewer",
-		})
+		// the KMS keyring is only required by the gcpckms seal
+		if googleConfig.EncryptionKey != nil {
+			createKeyringBindings(ctx, googleConfig, email)
+		}
 
 		_, _ = role.CreateMember(ctx, fmt.Sprintf("%s-dns-admin", email), &role.MemberOptions{
 			Member:  pulumi.Sprintf("serviceAccount:%s", email),
@@ -61,3 +44,31 @@ func Create(ctx *pulumi.Context, googleConfig *google.Config, dnsConfig *dns.Con
 
 	return iam, nil
 }
+
+// createKeyringBindings grants the service account access to the KMS keyring used by the gcpckms seal.
+// ctx: Pulumi context for resource management.
+// googleConfig: Configuration details for Google Cloud.
+// email: The email of the service account.
+func createKeyringBindings(ctx *pulumi.Context, googleConfig *google.Config, email string) {
+	keyringID := fmt.Sprintf(
+		"%s/%s/%s",
+		*googleConfig.Project,
+		*googleConfig.EncryptionKey.Location,
+		*googleConfig.EncryptionKey.KeyringID,
+	)
+	_, _ = kmsIam.CreateKeyringBinding(ctx, &kmsIam.KeyringBindingOptions{
+		KeyRingID: keyringID,
+		Member:    fmt.Sprintf("serviceAccount:%s", email),
+		Role:      "roles/cloudkms.cryptoKeyEncrypterDecrypter",
+	})
+	_, _ = kmsIam.CreateKeyringBinding(ctx, &kmsIam.KeyringBindingOptions{
+		KeyRingID: keyringID,
+		Member:    fmt.Sprintf("serviceAccount:%s", email),
+		Role:      "roles/cloudkms.signerVerifier",
+	})
+	_, _ = kmsIam.CreateKeyringBinding(ctx, &kmsIam.KeyringBindingOptions{
+		KeyRingID: keyringID,
+		Member:    fmt.Sprintf("serviceAccount:%s", email),
+		Role:      "roles/cloudkms.viewer",
+	})
+}
@@ -2,17 +2,30 @@ package firewall
 
 import (
 	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
 	"strconv"
+	"strings"
 
 	slFirewall "github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/firewall"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
 	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	bgpConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
 	networkConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
 	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
 )
 
+const (
+	// directionIn is the direction of inbound rules.
+	directionIn = "in"
+	// directionOut is the direction of outbound rules.
+	directionOut = "out"
+)
+
 // networkAllCIDR defines the CIDR blocks that represent all IP addresses.
 //
 //nolint:gochecknoglobals // global is acceptable here
@@ -22,10 +35,12 @@ var networkAllCIDR = []pulumi.StringInput{pulumi.String("0.0.0.0/0"), pulumi.Str
 // ctx: Pulumi context
 // networkConfig: Configuration for the Hetzner network.
 // serverConfig: Configuration for the Hetzner server.
+// bgpConfig: Configuration for BGP, used to allow the GRE tunnels of the neighbors.
 func Create(
 	ctx *pulumi.Context,
 	networkConfig *networkConf.Config,
 	serverConfig *serverConf.Config,
+	bgpConfig *bgpConf.Config,
 ) (*hcloud.Firewall, error) {
 	sshSourceIps := networkAllCIDR
 	if !*serverConfig.PublicSSH {
@@ -33,29 +48,26 @@ func Create(
 	}
 	sshRule := slFirewall.Rule{
 		Description: pulumi.String("Allow incoming SSH traffic"),
-		Direction:   "in",
+		Direction:   directionIn,
 		Port:        "22",
 		Protocol:    "tcp",
 		SourceIPs:   sshSourceIps,
 	}
 
 	rules := []slFirewall.Rule{sshRule}
-	for _, rule := range networkConfig.FirewallRules {
-		rSourceIps := networkAllCIDR
-		if rule.SourceIPs != nil {
-			rSourceIps = []pulumi.StringInput{}
-			for _, ip := range rule.SourceIPs {
-				rSourceIps = append(rSourceIps, pulumi.String(ip))
-			}
+	for _, name := range slices.Sorted(maps.Keys(networkConfig.FirewallRules)) {
+		rule, err := firewallRule(name, networkConfig.FirewallRules[name])
+		if err != nil {
+			return nil, err
 		}
-		rules = append(rules, slFirewall.Rule{
-			Description: pulumi.String(*rule.Description),
-			Direction:   "in",
-			Port:        strconv.Itoa(*rule.Port),
-			Protocol:    *rule.Protocol,
-			SourceIPs:   rSourceIps,
-		})
+		rules = append(rules, *rule)
+	}
+
+	greRules, gErr := greRules(bgpConfig)
+	if gErr != nil {
+		return nil, gErr
 	}
+	rules = append(rules, greRules...)
 
 	return slFirewall.Create(ctx, config.GlobalName, &slFirewall.CreateOptions{
 		Name:   fmt.Sprintf("%s-%s", config.GlobalName, config.Environment),
@@ -63,3 +75,126 @@ func Create(
 		Rules:  rules,
 	})
 }
+
+// firewallRule converts a firewall rule configuration into a firewall rule.
+// name: The name of the firewall rule.
+// rule: The firewall rule configuration.
+func firewallRule(name string, rule *networkConf.FirewallRule) (*slFirewall.Rule, error) {
+	direction := defaults.GetOrDefault(rule.Direction, directionIn)
+	if direction != directionIn && direction != directionOut {
+		return nil, fmt.Errorf("%w: %s: unknown direction %q", ErrInvalidFirewallRule, name, direction)
+	}
+
+	protocol := defaults.GetOrDefault(rule.Protocol, "")
+	port, pErr := rulePort(name, protocol, rule)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	fwRule := &slFirewall.Rule{
+		Description: pulumi.String(defaults.GetOrDefault(rule.Description, name)),
+		Direction:   direction,
+		Port:        port,
+		Protocol:    protocol,
+	}
+	switch direction {
+	case directionIn:
+		if len(rule.DestinationIPs) > 0 {
+			return nil, fmt.Errorf("%w: %s: inbound rules have no destination IPs", ErrInvalidFirewallRule, name)
+		}
+		fwRule.SourceIPs = ruleIPs(rule.SourceIPs)
+	case directionOut:
+		if len(rule.SourceIPs) > 0 {
+			return nil, fmt.Errorf("%w: %s: outbound rules have no source IPs", ErrInvalidFirewallRule, name)
+		}
+		fwRule.DestinationIPs = ruleIPs(rule.DestinationIPs)
+	}
+	return fwRule, nil
+}
+
+// rulePort returns the port or port range of a firewall rule.
+// Only TCP and UDP rules have ports.
+// name: The name of the firewall rule.
+// protocol: The protocol of the firewall rule.
+// rule: The firewall rule configuration.
+func rulePort(name string, protocol string, rule *networkConf.FirewallRule) (string, error) {
+	switch protocol {
+	case "tcp", "udp":
+		if rule.Port == nil {
+			return "", fmt.Errorf("%w: %s: %s rules require a port", ErrInvalidFirewallRule, name, protocol)
+		}
+		if rule.PortEnd == nil {
+			return strconv.Itoa(*rule.Port), nil
+		}
+		if *rule.PortEnd < *rule.Port {
+			return "", fmt.Errorf("%w: %s: the port range ends before it starts", ErrInvalidFirewallRule, name)
+		}
+		return fmt.Sprintf("%d-%d", *rule.Port, *rule.PortEnd), nil
+	case "icmp", "gre", "esp":
+		if rule.Port != nil || rule.PortEnd != nil {
+			return "", fmt.Errorf("%w: %s: %s rules have no ports", ErrInvalidFirewallRule, name, protocol)
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: %s: unknown protocol %q", ErrInvalidFirewallRule, name, protocol)
+	}
+}
+
+// ruleIPs returns the IPs of a firewall rule, defaulting to all IP addresses.
+// ips: The configured IPs.
+func ruleIPs(ips []string) []pulumi.StringInput {
+	if ips == nil {
+		return networkAllCIDR
+	}
+	ruleIps := make([]pulumi.StringInput, 0, len(ips))
+	for _, ip := range ips {
+		ruleIps = append(ruleIps, pulumi.String(ip))
+	}
+	return ruleIps
+}
+
+// greRules returns the inbound GRE rules of the BGP neighbors' tunnels.
+// bgpConfig: Configuration for BGP.
+func greRules(bgpConfig *bgpConf.Config) ([]slFirewall.Rule, error) {
+	if bgpConfig == nil {
+		return nil, nil
+	}
+
+	var rules []slFirewall.Rule
+	for _, name := range slices.Sorted(maps.Keys(bgpConfig.Neighbors)) {
+		neighbor := bgpConfig.Neighbors[name]
+		if neighbor == nil || neighbor.GRE == nil || neighbor.GRE.RemoteIP == nil {
+			continue
+		}
+
+		cidr, err := hostCIDR(*neighbor.GRE.RemoteIP)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bgp neighbor %s: %w", ErrInvalidFirewallRule, name, err)
+		}
+		rules = append(rules, slFirewall.Rule{
+			Description: pulumi.String(fmt.Sprintf("Allow incoming GRE traffic of BGP neighbor %s", name)),
+			Direction:   directionIn,
+			Protocol:    "gre",
+			SourceIPs:   []pulumi.StringInput{pulumi.String(cidr)},
+		})
+	}
+	return rules, nil
+}
+
+// hostCIDR returns the host CIDR of an IP address, or the prefix if it already is one.
+// ip: The IP address or prefix.
+func hostCIDR(ip string) (string, error) {
+	if strings.Contains(ip, "/") {
+		prefix, err := netip.ParsePrefix(ip)
+		if err != nil {
+			return "", err
+		}
+		return prefix.String(), nil
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+}
@@ -0,0 +1,6 @@
+package firewall
+
+import "errors"
+
+// ErrInvalidFirewallRule is returned if a firewall rule configuration is invalid.
+var ErrInvalidFirewallRule = errors.New("firewall: invalid rule")
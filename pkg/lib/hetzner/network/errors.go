@@ -0,0 +1,10 @@
+package network
+
+import "errors"
+
+var (
+	// ErrInvalidNetwork is returned if the existing network or subnet does not match the configuration.
+	ErrInvalidNetwork = errors.New("network: invalid network")
+	// ErrInvalidRoute is returned if a route destination or the network configuration is invalid.
+	ErrInvalidRoute = errors.New("network: invalid route")
+)
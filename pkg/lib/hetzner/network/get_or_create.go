@@ -1,8 +1,12 @@
 package network
 
 import (
+	"fmt"
+	"maps"
+
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/network"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/pulumi/convert"
+	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
@@ -10,13 +14,24 @@ import (
 )
 
 // GetOrCreate retrieves an existing Hetzner network or creates a new one based on the provided configuration.
+// The network is looked up by its label selector, if configured, or by its name.
+// A network carrying the labels of this stack was created by it and stays managed; other networks are shared.
+// Returns the ID of the network and whether it is shared.
 // ctx: Pulumi context
 // networkConfig: Configuration for the Hetzner network.
-func GetOrCreate(ctx *pulumi.Context, networkConfig *networkConf.Config) (*pulumi.IntOutput, error) {
-	lNet, lErr := network.Get(ctx, *networkConfig.Name)
-	if lErr == nil && lNet != nil {
-		id := pulumi.Int(lNet.Id).ToIntOutput()
-		return &id, nil
+func GetOrCreate(ctx *pulumi.Context, networkConfig *networkConf.Config) (*pulumi.IntOutput, bool, error) {
+	existing, lErr := lookup(ctx, networkConfig)
+	if lErr != nil {
+		return nil, false, lErr
+	}
+
+	if existing != nil && !maps.Equal(existing.Labels, config.CommonLabels()) {
+		if existing.IpRange != *networkConfig.CIDR {
+			return nil, false, fmt.Errorf("%w: %s: CIDR %s does not match the configured CIDR %s",
+				ErrInvalidNetwork, existing.Name, existing.IpRange, *networkConfig.CIDR)
+		}
+		id := pulumi.Int(existing.Id).ToIntOutput()
+		return &id, true, nil
 	}
 
 	cNet, cErr := network.Create(ctx, &network.CreateOptions{
@@ -25,8 +40,39 @@ func GetOrCreate(ctx *pulumi.Context, networkConfig *networkConf.Config) (*pulum
 		Labels: config.CommonLabels(),
 	})
 	if cErr != nil {
-		return nil, cErr
+		return nil, false, cErr
 	}
 	id := convert.IDToInt(cNet.ID())
-	return &id, nil
+	return &id, false, nil
+}
+
+// lookup returns the existing Hetzner network, if any.
+// ctx: Pulumi context
+// networkConfig: Configuration for the Hetzner network.
+func lookup(ctx *pulumi.Context, networkConfig *networkConf.Config) (*hcloud.GetNetworksNetwork, error) {
+	networks, nErr := hcloud.GetNetworks(ctx, &hcloud.GetNetworksArgs{
+		WithSelector: networkConfig.Selector,
+	})
+	if nErr != nil {
+		return nil, nErr
+	}
+
+	var matches []hcloud.GetNetworksNetwork
+	for _, candidate := range networks.Networks {
+		if networkConfig.Selector != nil || candidate.Name == *networkConfig.Name {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if networkConfig.Selector != nil {
+			return nil, fmt.Errorf("%w: no network matches the selector %q", ErrInvalidNetwork, *networkConfig.Selector)
+		}
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %d networks match", ErrInvalidNetwork, len(matches))
+	}
 }
@@ -0,0 +1,135 @@
+package network
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/tailscale"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/wireguard"
+	bgpConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
+	networkConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+)
+
+// CreateRoutes creates the routes of the network via the server for the prefixes reachable through it:
+// the BGP internal networks, the WireGuard clients, the Tailscale advertised routes and the configured destinations.
+// Destinations outside the network or overlapping the subnet are skipped, as are destinations within another one.
+// ctx: Pulumi context
+// networkID: The ID of the network.
+// gateway: The private IP address of the server.
+// networkConfig: The network configuration.
+// bgpConfig: The BGP configuration.
+// dependsOn: Pulumi resource option to specify dependencies.
+func CreateRoutes(
+	ctx *pulumi.Context,
+	networkID pulumi.IntOutput,
+	gateway string,
+	networkConfig *networkConf.Config,
+	bgpConfig *bgpConf.Config,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) ([]pulumi.Resource, error) {
+	var routesConfig networkConf.RoutesConfig
+	if networkConfig.Routes != nil {
+		routesConfig = *networkConfig.Routes
+	}
+	if !defaults.GetOrDefault(routesConfig.Enabled, true) {
+		return nil, nil
+	}
+
+	destinations, dErr := routeDestinations(networkConfig, bgpConfig, routesConfig.Destinations)
+	if dErr != nil {
+		return nil, dErr
+	}
+
+	resources := make([]pulumi.Resource, 0, len(destinations))
+	for _, destination := range destinations {
+		name := strings.NewReplacer(".", "-", "/", "-").Replace(destination.String())
+		route, rErr := hcloud.NewNetworkRoute(
+			ctx,
+			fmt.Sprintf("hcloud-network-route-%s-%s", config.GlobalName, name),
+			&hcloud.NetworkRouteArgs{
+				NetworkId:   networkID,
+				Destination: pulumi.String(destination.String()),
+				Gateway:     pulumi.String(gateway),
+			},
+			dependsOn,
+		)
+		if rErr != nil {
+			return nil, rErr
+		}
+		resources = append(resources, route)
+	}
+	return resources, nil
+}
+
+// routeDestinations returns the sorted destinations to route via the server.
+// networkConfig: The network configuration.
+// bgpConfig: The BGP configuration.
+// additional: The additionally configured destinations.
+func routeDestinations(
+	networkConfig *networkConf.Config,
+	bgpConfig *bgpConf.Config,
+	additional []string,
+) ([]netip.Prefix, error) {
+	network, nErr := netip.ParsePrefix(*networkConfig.CIDR)
+	if nErr != nil {
+		return nil, fmt.Errorf("%w: network: %w", ErrInvalidRoute, nErr)
+	}
+	subnet, sErr := netip.ParsePrefix(*networkConfig.SubnetCIDR)
+	if sErr != nil {
+		return nil, fmt.Errorf("%w: subnet: %w", ErrInvalidRoute, sErr)
+	}
+
+	candidates := []string{wireguard.ClientCIDRv4}
+	candidates = append(candidates, tailscale.AdvertisedRoutes...)
+	if bgpConfig.InternalNetworks != nil {
+		candidates = append(candidates, bgpConfig.InternalNetworks.IPv4...)
+	}
+	candidates = append(candidates, additional...)
+
+	var prefixes []netip.Prefix
+	for _, candidate := range candidates {
+		prefix, pErr := netip.ParsePrefix(candidate)
+		if pErr != nil {
+			return nil, fmt.Errorf("%w: destination: %w", ErrInvalidRoute, pErr)
+		}
+		prefix = prefix.Masked()
+		if !prefix.Addr().Is4() {
+			continue
+		}
+		if prefix.Bits() < network.Bits() || !network.Contains(prefix.Addr()) || prefix.Overlaps(subnet) {
+			log.Warn().
+				Msgf("[hetzner][network] skipping route %s outside of network %s or overlapping subnet %s",
+					prefix, network, subnet)
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	// broader prefixes first, so prefixes within them are skipped
+	slices.SortFunc(prefixes, func(a netip.Prefix, b netip.Prefix) int {
+		if a.Bits() != b.Bits() {
+			return a.Bits() - b.Bits()
+		}
+		return a.Addr().Compare(b.Addr())
+	})
+	destinations := make([]netip.Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if slices.ContainsFunc(destinations, prefix.Overlaps) {
+			continue
+		}
+		destinations = append(destinations, prefix)
+	}
+
+	slices.SortFunc(destinations, func(a netip.Prefix, b netip.Prefix) int {
+		return a.Addr().Compare(b.Addr())
+	})
+	return destinations, nil
+}
@@ -0,0 +1,80 @@
+package network
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/network/subnet"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	networkConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+)
+
+// networkZones maps the Hetzner locations to their network zones.
+//
+//nolint:gochecknoglobals // global is acceptable here
+var networkZones = map[string]string{
+	"fsn1": "eu-central",
+	"nbg1": "eu-central",
+	"hel1": "eu-central",
+	"ash":  "us-east",
+	"hil":  "us-west",
+	"sin":  "ap-southeast",
+}
+
+// CreateSubnet creates the subnet of the server in the network.
+// An existing subnet of a shared network is imported instead, and retained when the stack is destroyed.
+// ctx: Pulumi context
+// networkID: The ID of the network.
+// shared: Whether the network is shared with other stacks.
+// location: The location of the server.
+// networkConfig: Configuration for the Hetzner network.
+func CreateSubnet(
+	ctx *pulumi.Context,
+	networkID *pulumi.IntOutput,
+	shared bool,
+	location string,
+	networkConfig *networkConf.Config,
+) (*hcloud.NetworkSubnet, error) {
+	network, nErr := netip.ParsePrefix(*networkConfig.CIDR)
+	if nErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNetwork, nErr)
+	}
+	subnetCIDR, sErr := netip.ParsePrefix(*networkConfig.SubnetCIDR)
+	if sErr != nil {
+		return nil, fmt.Errorf("%w: subnet: %w", ErrInvalidNetwork, sErr)
+	}
+	if subnetCIDR.Bits() < network.Bits() || !network.Contains(subnetCIDR.Addr()) {
+		return nil, fmt.Errorf("%w: subnet %s is not within the network %s", ErrInvalidNetwork, subnetCIDR, network)
+	}
+
+	if !defaults.GetOrDefault(networkConfig.ExistingSubnet, false) {
+		return subnet.Create(ctx, config.GlobalName, &subnet.CreateOptions{
+			NetworkID: networkID,
+			Cidr:      *networkConfig.SubnetCIDR,
+		})
+	}
+
+	if !shared {
+		return nil, fmt.Errorf("%w: the existing subnet %s requires an existing network", ErrInvalidNetwork, subnetCIDR)
+	}
+	networkZone, ok := networkZones[location]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown network zone of location %q", ErrInvalidNetwork, location)
+	}
+	return hcloud.NewNetworkSubnet(ctx, fmt.Sprintf("hcloud-network-subnet-%s", config.GlobalName),
+		&hcloud.NetworkSubnetArgs{
+			NetworkId:   networkID,
+			Type:        pulumi.String("cloud"),
+			NetworkZone: pulumi.String(networkZone),
+			IpRange:     pulumi.String(subnetCIDR.String()),
+		},
+		pulumi.Import(networkID.ApplyT(func(id int) pulumi.ID {
+			return pulumi.ID(fmt.Sprintf("%d-%s", id, subnetCIDR))
+		}).(pulumi.IDOutput)),
+		pulumi.RetainOnDelete(true),
+	)
+}
@@ -3,17 +3,20 @@ package server
 import (
 	"fmt"
 
-	"github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/network/subnet"
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/primaryip"
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/server"
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/hetzner/sshkey"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/hetzner/location"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/pulumi/convert"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/cloudinit"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/hetzner/firewall"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/hetzner/network"
+	bgpConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
+	dnsConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
 	networkConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
 	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
 	serverModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
@@ -28,11 +31,17 @@ type PrimaryIPs struct {
 // Create creates a new Hetzner server.
 // ctx: Pulumi context
 // publicSSHKey: Public SSH key to be added to the server for access.
+// serverConfig: The server configuration.
+// networkConfig: The network configuration.
+// dnsConfig: The DNS configuration.
+// bgpConfig: The BGP configuration.
 func Create(
 	ctx *pulumi.Context,
 	publicSSHKey pulumi.StringOutput,
 	serverConfig *serverConf.Config,
 	networkConfig *networkConf.Config,
+	dnsConfig *dnsConf.Config,
+	bgpConfig *bgpConf.Config,
 ) (*serverModel.Data, error) {
 	// location & datacenter
 	dc := location.ToDatacenter(serverConfig.Location)
@@ -48,16 +57,18 @@ func Create(
 	}
 
 	// network
-	network, nErr := network.GetOrCreate(ctx, networkConfig)
+	networkID, shared, nErr := network.GetOrCreate(ctx, networkConfig)
 	if nErr != nil {
 		return nil, nErr
 	}
-	_, _ = subnet.Create(ctx, config.GlobalName, &subnet.CreateOptions{
-		NetworkID: network,
-		Cidr:      *networkConfig.SubnetCIDR,
-	})
+	networkSubnet, snErr := network.CreateSubnet(ctx, networkID, shared, *serverConfig.Location, networkConfig)
+	if snErr != nil {
+		return nil, snErr
+	}
+	// the server attaches to the network via the subnet to depend on it
+	subnetNetworkID := networkSubnet.NetworkId
 
-	firewall, fErr := firewall.Create(ctx, networkConfig, serverConfig)
+	firewall, fErr := firewall.Create(ctx, networkConfig, serverConfig, bgpConfig)
 	if fErr != nil {
 		return nil, fErr
 	}
@@ -86,29 +97,39 @@ func Create(
 		return nil, pv6Err
 	}
 
+	sshIP := pulumi.String(*serverConfig.IPv4).ToStringOutput()
+	if *serverConfig.PublicSSH {
+		sshIP = primaryIPv4.IpAddress
+	}
+
+	// cloud-init
+	userData, uErr := cloudinit.UserData(sshIP, publicSSHKey, bgpConfig)
+	if uErr != nil {
+		return nil, uErr
+	}
+	if wErr := withUserData(ctx, userData); wErr != nil {
+		return nil, wErr
+	}
+
 	// server
+	hostname := fmt.Sprintf("%s-%s-%s", config.GlobalName, config.Environment, *serverConfig.Location)
 	enableIPv6 := false
 	server, sErr := server.Create(
 		ctx,
 		fmt.Sprintf("%s-%s", config.GlobalName, *serverConfig.Location),
 		&server.CreateOptions{
-			Hostname: pulumi.Sprintf(
-				"%s-%s-%s",
-				config.GlobalName,
-				config.Environment,
-				*serverConfig.Location,
-			),
+			Hostname:           pulumi.String(hostname),
 			ServerType:         pulumi.String(*serverConfig.Type),
 			Image:              pulumi.String("ubuntu-24.04"),
 			SSHKeys:            []pulumi.StringInput{hetznerSSHKey.ID().ToStringOutput()},
 			Location:           pulumi.String(*serverConfig.Location),
-			NetworkID:          network,
+			NetworkID:          &subnetNetworkID,
 			IPAddress:          pulumi.String(*serverConfig.IPv4),
 			PrimaryIPv4Address: primaryIPv4,
 			PrimaryIPv6Address: primaryIPv6,
 			EnableIPv6:         &enableIPv6,
 			Firewalls:          []pulumi.IntInput{convert.IDToInt(firewall.ID())},
-			Backups:            pulumi.Bool(false),
+			Backups:            pulumi.Bool(defaults.GetOrDefault(serverConfig.Backups, false)),
 			Protection:         true,
 			Labels:             config.CommonLabels(),
 			PublicSSH:          *serverConfig.PublicSSH,
@@ -118,17 +139,58 @@ func Create(
 		return nil, sErr
 	}
 
-	sshIP := pulumi.String(*serverConfig.IPv4).ToStringOutput()
-	if *serverConfig.PublicSSH {
-		sshIP = primaryIPv4.IpAddress
+	// network routes
+	_, nrErr := network.CreateRoutes(
+		ctx,
+		*networkID,
+		*serverConfig.IPv4,
+		networkConfig,
+		bgpConfig,
+		pulumi.DependsOn([]pulumi.Resource{server.Resource}),
+	)
+	if nrErr != nil {
+		return nil, nrErr
+	}
+
+	// floating IPs
+	floatingIPs, fiErr := createFloatingIPs(ctx, server.Resource, serverConfig)
+	if fiErr != nil {
+		return nil, fiErr
+	}
+
+	// volumes
+	volumes, vErr := createVolumes(ctx, server.Resource, serverConfig)
+	if vErr != nil {
+		return nil, vErr
+	}
+
+	// reverse DNS
+	publicIPv6 := pulumi.Sprintf("%s1", primaryIPv6.IpAddress)
+	rErr := createReverseDNS(
+		ctx,
+		hostname,
+		primaryIPv4,
+		primaryIPv6,
+		publicIPv6,
+		floatingIPs,
+		serverConfig,
+		networkConfig,
+		dnsConfig,
+	)
+	if rErr != nil {
+		return nil, rErr
 	}
+
 	return &serverModel.Data{
 		Resource:    server.Resource,
+		ID:          server.Resource.ID(),
 		Hostname:    server.Hostname,
 		PrivateIPv4: pulumi.String(*serverConfig.IPv4).ToStringOutput(),
 		PublicIPv4:  primaryIPv4.IpAddress,
-		PublicIPv6:  pulumi.Sprintf("%s1", primaryIPv6.IpAddress),
+		PublicIPv6:  publicIPv6,
+		FloatingIPs: floatingIPs,
 		SSHIPv4:     sshIP,
 		Network:     pulumi.String(*networkConfig.Name).ToStringOutput(),
+		Volumes:     volumes,
 	}, nil
 }
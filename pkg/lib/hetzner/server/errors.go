@@ -0,0 +1,10 @@
+package server
+
+import "errors"
+
+var (
+	// ErrInvalidReverseDNS is returned if the reverse DNS name is missing.
+	ErrInvalidReverseDNS = errors.New("server: invalid reverse DNS")
+	// ErrInvalidVolume is returned if a volume configuration is invalid.
+	ErrInvalidVolume = errors.New("server: invalid volume")
+)
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/pulumi/convert"
+	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	serverModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+)
+
+// createFloatingIPs creates the floating IPs of the server, or reads the ones shared with other core servers.
+// Created floating IPs are assigned to the server; the failover reassigns them afterwards, hence changes are ignored.
+// ctx: Pulumi context
+// server: The server to assign the floating IPs to.
+// serverConfig: The server configuration.
+func createFloatingIPs(
+	ctx *pulumi.Context,
+	server *hcloud.Server,
+	serverConfig *serverConf.Config,
+) (*serverModel.FloatingIPs, error) {
+	if serverConfig.FloatingIPs == nil {
+		return nil, nil
+	}
+
+	ipv4, v4Err := floatingIP(ctx, "ipv4", serverConfig.FloatingIPs.IPv4ID, server, serverConfig)
+	if v4Err != nil {
+		return nil, v4Err
+	}
+	ipv6, v6Err := floatingIP(ctx, "ipv6", serverConfig.FloatingIPs.IPv6ID, server, serverConfig)
+	if v6Err != nil {
+		return nil, v6Err
+	}
+
+	return &serverModel.FloatingIPs{
+		IPv4ID: convert.IDToInt(ipv4.ID()),
+		IPv4:   ipv4.IpAddress,
+		IPv6ID: convert.IDToInt(ipv6.ID()),
+		IPv6:   pulumi.Sprintf("%s1", ipv6.IpAddress),
+	}, nil
+}
+
+// floatingIP creates a floating IP assigned to the server, or reads an existing one.
+// ctx: Pulumi context
+// ipType: The type of the floating IP, one of ipv4, ipv6.
+// id: The ID of an existing floating IP.
+// server: The server to assign the floating IP to.
+// serverConfig: The server configuration.
+func floatingIP(
+	ctx *pulumi.Context,
+	ipType string,
+	id *int,
+	server *hcloud.Server,
+	serverConfig *serverConf.Config,
+) (*hcloud.FloatingIp, error) {
+	name := fmt.Sprintf("hcloud-floating-ip-%s-%s", config.GlobalName, ipType)
+	if id != nil {
+		return hcloud.GetFloatingIp(ctx, name, pulumi.ID(strconv.Itoa(*id)), nil)
+	}
+
+	return hcloud.NewFloatingIp(ctx, name, &hcloud.FloatingIpArgs{
+		Name:             pulumi.Sprintf("%s-%s-%s", config.GlobalName, config.Environment, ipType),
+		Type:             pulumi.String(ipType),
+		HomeLocation:     pulumi.String(*serverConfig.Location),
+		ServerId:         convert.IDToInt(server.ID()),
+		DeleteProtection: pulumi.Bool(true),
+		Labels:           pulumi.ToStringMap(config.CommonLabels()),
+	}, pulumi.IgnoreChanges([]string{"serverId"}))
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/pulumi/convert"
+	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/rs/zerolog/log"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	dnsConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	networkConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	serverModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+)
+
+// createReverseDNS sets the reverse DNS (PTR) records of the public IPs.
+// The PTR is set on the floating IPs created for the server, if configured, because the public DNS entries
+// point to them; otherwise on the primary IPs.
+// ctx: Pulumi context
+// hostname: The hostname of the server.
+// primaryIPv4: The primary IPv4.
+// primaryIPv6: The primary IPv6.
+// publicIPv6: The public IPv6 address within the primary IPv6 network.
+// floatingIPs: The floating IPs of the server.
+// serverConfig: The server configuration.
+// networkConfig: The network configuration.
+// dnsConfig: The DNS configuration to check the forward records against.
+func createReverseDNS(
+	ctx *pulumi.Context,
+	hostname string,
+	primaryIPv4 *hcloud.PrimaryIp,
+	primaryIPv6 *hcloud.PrimaryIp,
+	publicIPv6 pulumi.StringOutput,
+	floatingIPs *serverModel.FloatingIPs,
+	serverConfig *serverConf.Config,
+	networkConfig *networkConf.Config,
+	dnsConfig *dnsConf.Config,
+) error {
+	ptr, pErr := reverseDNSName(hostname, serverConfig, networkConfig)
+	if pErr != nil {
+		return pErr
+	}
+	if !isForwardConfirmed(ptr, dnsConfig) {
+		log.Warn().
+			Msgf("[hetzner][server] reverse DNS name %s is not forward-confirmed by a public DNS entry", ptr)
+	}
+
+	if floatingIPs != nil {
+		if serverConfig.FloatingIPs.IPv4ID == nil {
+			_, v4Err := hcloud.NewRdns(ctx, fmt.Sprintf("hcloud-rdns-%s-floating-ipv4", config.GlobalName), &hcloud.RdnsArgs{
+				FloatingIpId: floatingIPs.IPv4ID,
+				IpAddress:    floatingIPs.IPv4,
+				DnsPtr:       pulumi.String(ptr),
+			})
+			if v4Err != nil {
+				return v4Err
+			}
+		}
+		if serverConfig.FloatingIPs.IPv6ID == nil {
+			_, v6Err := hcloud.NewRdns(ctx, fmt.Sprintf("hcloud-rdns-%s-floating-ipv6", config.GlobalName), &hcloud.RdnsArgs{
+				FloatingIpId: floatingIPs.IPv6ID,
+				IpAddress:    floatingIPs.IPv6,
+				DnsPtr:       pulumi.String(ptr),
+			})
+			if v6Err != nil {
+				return v6Err
+			}
+		}
+		return nil
+	}
+
+	_, v4Err := hcloud.NewRdns(ctx, fmt.Sprintf("hcloud-rdns-%s-ipv4", config.GlobalName), &hcloud.RdnsArgs{
+		PrimaryIpId: convert.IDToInt(primaryIPv4.ID()),
+		IpAddress:   primaryIPv4.IpAddress,
+		DnsPtr:      pulumi.String(ptr),
+	})
+	if v4Err != nil {
+		return v4Err
+	}
+
+	_, v6Err := hcloud.NewRdns(ctx, fmt.Sprintf("hcloud-rdns-%s-ipv6", config.GlobalName), &hcloud.RdnsArgs{
+		PrimaryIpId: convert.IDToInt(primaryIPv6.ID()),
+		IpAddress:   publicIPv6,
+		DnsPtr:      pulumi.String(ptr),
+	})
+	return v6Err
+}
+
+// reverseDNSName returns the PTR name: the configured one or the hostname within the network DNS suffix.
+// hostname: The hostname of the server.
+// serverConfig: The server configuration.
+// networkConfig: The network configuration.
+func reverseDNSName(
+	hostname string,
+	serverConfig *serverConf.Config,
+	networkConfig *networkConf.Config,
+) (string, error) {
+	if serverConfig.ReverseDNS != nil && *serverConfig.ReverseDNS != "" {
+		return *serverConfig.ReverseDNS, nil
+	}
+	if networkConfig.DNSSuffix == nil || *networkConfig.DNSSuffix == "" {
+		return "", fmt.Errorf("%w: server.reverseDns or network.dnsSuffix is required", ErrInvalidReverseDNS)
+	}
+	return fmt.Sprintf("%s.%s", hostname, strings.TrimPrefix(*networkConfig.DNSSuffix, ".")), nil
+}
+
+// isForwardConfirmed returns whether a public A and AAAA DNS entry confirms the PTR name.
+// Public DNS entries point to the floating IPs, if configured, which carry the PTR then.
+// ptr: The PTR name.
+// dnsConfig: The DNS configuration.
+func isForwardConfirmed(ptr string, dnsConfig *dnsConf.Config) bool {
+	for _, entry := range dnsConfig.Entries {
+		if entry.Domain == nil || strings.TrimSuffix(*entry.Domain, ".") != strings.TrimSuffix(ptr, ".") {
+			continue
+		}
+		if len(entry.Values) == 0 && defaults.GetOrDefault(entry.Target, "public") == "public" &&
+			entry.Type == nil {
+			return true
+		}
+	}
+	return false
+}
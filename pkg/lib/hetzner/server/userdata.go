@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// serverResourceType is the Pulumi type of Hetzner servers.
+const serverResourceType = "hcloud:index/server:Server"
+
+// withUserData sets the cloud-init user-data of the Hetzner servers created afterwards.
+// The server options of the shared library do not expose the user-data, hence it is set by a resource transform.
+// Changes are ignored to not replace running servers; the user-data applies to rebuilt servers.
+// The SSH keys are ignored as well, since a rotated key is authorized by the deploy user setup instead.
+// ctx: Pulumi context
+// userData: The cloud-init user-data.
+func withUserData(ctx *pulumi.Context, userData pulumi.StringOutput) error {
+	return ctx.RegisterResourceTransform(
+		func(_ context.Context, args *pulumi.ResourceTransformArgs) *pulumi.ResourceTransformResult {
+			if args.Type != serverResourceType {
+				return nil
+			}
+
+			props := args.Props
+			if props == nil {
+				props = pulumi.Map{}
+			}
+			props["userData"] = userData
+
+			opts := args.Opts
+			opts.IgnoreChanges = append(opts.IgnoreChanges, "userData", "sshKeys")
+			return &pulumi.ResourceTransformResult{
+				Props: props,
+				Opts:  opts,
+			}
+		},
+	)
+}
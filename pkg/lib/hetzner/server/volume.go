@@ -0,0 +1,85 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/pulumi/convert"
+	"github.com/pulumi/pulumi-hcloud/sdk/go/hcloud"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	serverModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+)
+
+// defaultVolumeMountPath is the default mount path of volumes.
+const defaultVolumeMountPath = "/opt"
+
+// createVolumes creates the volumes and attaches them to the server.
+// ctx: Pulumi context
+// server: The server to attach the volumes to.
+// serverConfig: The server configuration.
+func createVolumes(
+	ctx *pulumi.Context,
+	server *hcloud.Server,
+	serverConfig *serverConf.Config,
+) ([]*serverModel.Volume, error) {
+	mountPaths := map[string]string{}
+	volumes := make([]*serverModel.Volume, 0, len(serverConfig.Volumes))
+	for _, name := range slices.Sorted(maps.Keys(serverConfig.Volumes)) {
+		volumeConfig := serverConfig.Volumes[name]
+		if volumeConfig == nil || volumeConfig.Size == nil {
+			return nil, fmt.Errorf("%w: %s: missing size", ErrInvalidVolume, name)
+		}
+
+		format := defaults.GetOrDefault(volumeConfig.Format, "ext4")
+		if format != "ext4" && format != "xfs" {
+			return nil, fmt.Errorf("%w: %s: unknown format %q", ErrInvalidVolume, name, format)
+		}
+		mountPath := defaults.GetOrDefault(volumeConfig.MountPath, defaultVolumeMountPath)
+		if other, ok := mountPaths[mountPath]; ok {
+			return nil, fmt.Errorf("%w: %s: mount path %q is used by volume %s", ErrInvalidVolume, name, mountPath, other)
+		}
+		mountPaths[mountPath] = name
+
+		volume, vErr := hcloud.NewVolume(ctx, fmt.Sprintf("hcloud-volume-%s-%s", config.GlobalName, name),
+			&hcloud.VolumeArgs{
+				Name:             pulumi.Sprintf("%s-%s-%s", config.GlobalName, config.Environment, name),
+				Size:             pulumi.Int(*volumeConfig.Size),
+				Format:           pulumi.String(format),
+				Location:         pulumi.String(*serverConfig.Location),
+				DeleteProtection: pulumi.Bool(true),
+				Labels:           pulumi.ToStringMap(config.CommonLabels()),
+			},
+			pulumi.IgnoreChanges([]string{"format"}),
+		)
+		if vErr != nil {
+			return nil, vErr
+		}
+
+		attachment, aErr := hcloud.NewVolumeAttachment(
+			ctx,
+			fmt.Sprintf("hcloud-volume-attachment-%s-%s", config.GlobalName, name),
+			&hcloud.VolumeAttachmentArgs{
+				ServerId:  convert.IDToInt(server.ID()),
+				VolumeId:  convert.IDToInt(volume.ID()),
+				Automount: pulumi.Bool(false),
+			},
+		)
+		if aErr != nil {
+			return nil, aErr
+		}
+
+		volumes = append(volumes, &serverModel.Volume{
+			Name:      name,
+			MountPath: mountPath,
+			Format:    format,
+			Device:    volume.LinuxDevice,
+			Resource:  attachment,
+		})
+	}
+	return volumes, nil
+}
@@ -0,0 +1,6 @@
+package nftables
+
+import "errors"
+
+// ErrInvalidHostFirewall is returned if the host firewall configuration is invalid.
+var ErrInvalidHostFirewall = errors.New("nftables: invalid host firewall")
@@ -0,0 +1,152 @@
+package nftables
+
+import (
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// Install the nftables host firewall on the remote server via SSH.
+// The ruleset is applied atomically and rolled back unless a new SSH connection confirms it within two minutes.
+// Nothing is installed if no host firewall is configured.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// networkConfig: Network configuration.
+// serverConfig: Server configuration.
+// bgpConfig: BGP configuration.
+// dependsOn: Pulumi resource option to specify dependencies.
+func Install(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	networkConfig *network.Config,
+	serverConfig *server.Config,
+	bgpConfig *bgp.Config,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) (*remote.Command, error) {
+	if networkConfig.HostFirewall == nil {
+		return nil, nil
+	}
+
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	opts := []pulumi.ResourceOption{dependsOn}
+
+	opts, prepErr := install.Prepare(ctx, "nftables", conn, opts...)
+	if prepErr != nil {
+		return nil, prepErr
+	}
+
+	rulesetData, rdErr := rulesetTemplateData(networkConfig, serverConfig, bgpConfig)
+	if rdErr != nil {
+		return nil, rdErr
+	}
+	ruleset, rErr := template.Render("./assets/nftables/ruleset.nft.j2", rulesetData)
+	if rErr != nil {
+		return nil, rErr
+	}
+	rulesetHash := file.WritePulumi("./outputs/nftables_ruleset.nft", pulumi.String(ruleset)).
+		ApplyT(func(_ string) string {
+			hash, _ := file.Hash("./outputs/nftables_ruleset.nft")
+			return *hash
+		})
+	rulesetCopy := rulesetHash.ApplyT(func(_ string) pulumi.ResourceOption {
+		cmd, _ := install.CopyToRemote(
+			ctx,
+			"remote-copy-nftables-ruleset",
+			&remote.CopyToRemoteArgs{
+				Source:     pulumi.NewFileAsset("./outputs/nftables_ruleset.nft"),
+				RemotePath: pulumi.String("/opt/nftables/ruleset.nft.pending"),
+				Triggers:   pulumi.Array{rulesetHash},
+				Connection: conn,
+			},
+			0o644,
+			opts...)
+		return pulumi.DependsOn([]pulumi.Resource{cmd})
+	})
+
+	rollbackHash, rhErr := file.Hash("./assets/nftables/rollback.sh")
+	if rhErr != nil {
+		return nil, rhErr
+	}
+	rollbackCopy, rcErr := install.CopyToRemote(
+		ctx,
+		"remote-copy-nftables-rollback",
+		&remote.CopyToRemoteArgs{
+			Source:     pulumi.NewFileAsset("./assets/nftables/rollback.sh"),
+			RemotePath: pulumi.String("/opt/nftables/rollback.sh"),
+			Triggers:   pulumi.Array{pulumi.String(*rollbackHash)},
+			Connection: conn,
+		},
+		0o700,
+		opts...)
+	if rcErr != nil {
+		return nil, rcErr
+	}
+	opts = append(opts, pulumi.DependsOn([]pulumi.Resource{rollbackCopy}))
+
+	opts, systemdServiceHash, shErr := install.SystemDService(ctx, "nftables", conn, opts...)
+	if shErr != nil {
+		return nil, shErr
+	}
+
+	triggers := pulumi.Array{rulesetHash, pulumi.String(*rollbackHash), pulumi.String(*systemdServiceHash)}
+
+	installFn, iErr := file.ReadContents("./assets/nftables/install.sh")
+	if iErr != nil {
+		return nil, iErr
+	}
+	waitFn, wErr := file.ReadContents("./assets/nftables/wait.sh")
+	if wErr != nil {
+		return nil, wErr
+	}
+	confirmFn, cErr := file.ReadContents("./assets/nftables/confirm.sh")
+	if cErr != nil {
+		return nil, cErr
+	}
+	opts = append(opts, install.CollectResourceOptions([]pulumi.Output{rulesetCopy})...)
+	timeouts := pulumi.Timeouts(&pulumi.CustomTimeouts{Create: "5m", Update: "5m"})
+
+	// the apply only succeeds once a new SSH connection confirmed the ruleset, so it is re-run after a rollback;
+	// the confirmation opens its connection after the wait for the ruleset to be applied
+	apply, aErr := remote.NewCommand(ctx, "remote-command-install-nftables", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(installFn),
+		Update:     pulumi.StringPtr(installFn),
+		Triggers:   triggers,
+		Connection: conn,
+	}, append(opts, timeouts)...)
+	if aErr != nil {
+		return nil, aErr
+	}
+
+	wait, waErr := remote.NewCommand(ctx, "remote-command-wait-nftables", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(waitFn),
+		Update:     pulumi.StringPtr(waitFn),
+		Triggers:   triggers,
+		Connection: conn,
+	}, append(opts, timeouts)...)
+	if waErr != nil {
+		return nil, waErr
+	}
+
+	_, coErr := remote.NewCommand(ctx, "remote-command-confirm-nftables", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(confirmFn),
+		Update:     pulumi.StringPtr(confirmFn),
+		Triggers:   triggers,
+		Connection: conn,
+	}, append(opts, pulumi.DependsOn([]pulumi.Resource{wait}))...)
+	if coErr != nil {
+		return nil, coErr
+	}
+
+	return apply, nil
+}
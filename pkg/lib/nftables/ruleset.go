@@ -0,0 +1,336 @@
+package nftables
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/bgp"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+)
+
+const (
+	// zonePublic is the zone of the public interface.
+	zonePublic = "public"
+	// zonePrivate is the zone of the private network interface.
+	zonePrivate = "private"
+	// zoneWireGuard is the zone of the WireGuard interfaces.
+	zoneWireGuard = "wireguard"
+	// zoneGRE is the zone of the GRE tunnels of the BGP neighbors.
+	zoneGRE = "gre"
+	// zoneTailscale is the zone of the Tailscale interface.
+	zoneTailscale = "tailscale"
+)
+
+// defaultZoneInterfaces are the default interface names per zone.
+//
+//nolint:gochecknoglobals // global is acceptable here
+var defaultZoneInterfaces = map[string][]string{
+	zonePublic:    {"eth0"},
+	zonePrivate:   {"enp7s0"},
+	zoneWireGuard: {"wg*"},
+	zoneGRE:       {"gre-*"},
+	zoneTailscale: {"tailscale0"},
+}
+
+// rulesetTemplateData returns the data to render the nftables ruleset with.
+// networkConfig: Network configuration.
+// serverConfig: Server configuration.
+// bgpConfig: BGP configuration.
+func rulesetTemplateData(
+	networkConfig *network.Config,
+	serverConfig *server.Config,
+	bgpConfig *bgp.Config,
+) (map[string]any, error) {
+	hostFirewall := networkConfig.HostFirewall
+
+	zoneInterfaces := map[string][]string{}
+	var zones []map[string]any
+	for _, name := range slices.Sorted(maps.Keys(hostFirewall.Zones)) {
+		zone := hostFirewall.Zones[name]
+		if zone == nil {
+			zone = &network.HostFirewallZoneConfig{}
+		}
+		if _, ok := defaultZoneInterfaces[name]; !ok {
+			return nil, fmt.Errorf("%w: %s: unknown zone", ErrInvalidHostFirewall, name)
+		}
+
+		interfaces := zone.Interfaces
+		if len(interfaces) == 0 {
+			interfaces = defaultZoneInterfaces[name]
+		}
+		zoneInterfaces[name] = interfaces
+
+		policy := defaults.GetOrDefault(zone.Policy, "drop")
+		if policy != "accept" && policy != "drop" {
+			return nil, fmt.Errorf("%w: %s: unknown policy %q", ErrInvalidHostFirewall, name, policy)
+		}
+
+		rules, dnat, rErr := zoneRules(name, zone, interfaces, networkConfig, serverConfig, bgpConfig)
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		zones = append(zones, map[string]any{
+			"name":       name,
+			"interfaces": interfaces,
+			"rules":      rules,
+			"dnat":       dnat,
+			"policy":     policy,
+		})
+	}
+
+	forward, fErr := forwardRules(hostFirewall.Forward, zoneInterfaces)
+	if fErr != nil {
+		return nil, fErr
+	}
+
+	return map[string]any{
+		"zones":     zones,
+		"forward":   forward,
+		"antiSpoof": antiSpoofRules(zoneInterfaces, bgpConfig),
+	}, nil
+}
+
+// zoneRules returns the inbound rules of a zone, and the rules of its destination NATed traffic.
+// SSH is always allowed on the public and private zones to not lock out the provisioning,
+// GRE is allowed for the tunnels of the BGP neighbors, BGP from the neighbors on their interfaces,
+// and VRRP for the failover peers of the floating IPs.
+// The firewall rules also apply to destination NATed traffic (e.g., the ports published by Docker).
+// name: The name of the zone.
+// zone: The zone configuration.
+// interfaces: The interface names of the zone.
+// networkConfig: Network configuration.
+// serverConfig: Server configuration.
+// bgpConfig: BGP configuration.
+func zoneRules(
+	name string,
+	zone *network.HostFirewallZoneConfig,
+	interfaces []string,
+	networkConfig *network.Config,
+	serverConfig *server.Config,
+	bgpConfig *bgp.Config,
+) ([]string, []string, error) {
+	var rules []string
+	switch name {
+	case zonePublic:
+		rules = append(rules, "tcp dport 22 accept")
+		for _, neighbor := range slices.Sorted(maps.Keys(bgpConfig.Neighbors)) {
+			gre := bgpConfig.Neighbors[neighbor].GRE
+			if gre != nil && gre.RemoteIP != nil {
+				rules = append(rules, sourceRules([]string{*gre.RemoteIP}, "meta l4proto gre accept")...)
+			}
+		}
+	case zonePrivate:
+		rules = append(rules, "tcp dport 22 accept")
+		if serverConfig.FloatingIPs != nil && serverConfig.FloatingIPs.Failover != nil &&
+			len(serverConfig.FloatingIPs.Failover.Peers) > 0 {
+			rules = append(rules, sourceRules(serverConfig.FloatingIPs.Failover.Peers, "meta l4proto vrrp accept")...)
+		}
+	}
+
+	for _, neighborName := range slices.Sorted(maps.Keys(bgpConfig.Neighbors)) {
+		neighbor := bgpConfig.Neighbors[neighborName]
+		if neighbor.InterfaceName == nil || !matchesInterface(interfaces, *neighbor.InterfaceName) {
+			continue
+		}
+		rules = append(rules, sourceRules(neighbor.Addresses,
+			fmt.Sprintf("iifname %q tcp dport 179 accept", *neighbor.InterfaceName))...)
+	}
+
+	var dnat []string
+	for _, ruleName := range zone.FirewallRules {
+		rule, ok := networkConfig.FirewallRules[ruleName]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s: unknown firewall rule %q", ErrInvalidHostFirewall, name, ruleName)
+		}
+		if defaults.GetOrDefault(rule.Direction, "in") != "in" {
+			return nil, nil, fmt.Errorf("%w: %s: firewall rule %q is not an inbound rule", ErrInvalidHostFirewall, name,
+				ruleName)
+		}
+
+		match, mErr := ruleMatch(ruleName, rule)
+		if mErr != nil {
+			return nil, nil, mErr
+		}
+		if match == "" {
+			continue
+		}
+		rules = append(rules, sourceRules(rule.SourceIPs, fmt.Sprintf("%s accept", match))...)
+		if nat := dnatMatch(rule); nat != "" {
+			dnat = append(dnat, sourceRules(rule.SourceIPs, fmt.Sprintf("%s accept", nat))...)
+		}
+	}
+	return rules, dnat, nil
+}
+
+// ruleMatch returns the protocol and port match of a firewall rule.
+// ICMP is always accepted and needs no match.
+// name: The name of the firewall rule.
+// rule: The firewall rule configuration.
+func ruleMatch(name string, rule *network.FirewallRule) (string, error) {
+	protocol := defaults.GetOrDefault(rule.Protocol, "")
+	switch protocol {
+	case "tcp", "udp":
+		if rule.Port == nil {
+			return "", fmt.Errorf("%w: firewall rule %s: missing port", ErrInvalidHostFirewall, name)
+		}
+		return fmt.Sprintf("%s dport %s", protocol, rulePort(rule)), nil
+	case "gre", "esp":
+		return fmt.Sprintf("meta l4proto %s", protocol), nil
+	case "icmp":
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: firewall rule %s: unknown protocol %q", ErrInvalidHostFirewall, name, protocol)
+	}
+}
+
+// dnatMatch returns the protocol and original port match of a firewall rule for destination NATed traffic.
+// Only TCP and UDP rules match, as other protocols are not published via destination NAT.
+// rule: The firewall rule configuration.
+func dnatMatch(rule *network.FirewallRule) string {
+	protocol := defaults.GetOrDefault(rule.Protocol, "")
+	if (protocol != "tcp" && protocol != "udp") || rule.Port == nil {
+		return ""
+	}
+	return fmt.Sprintf("meta l4proto %s ct original proto-dst %s", protocol, rulePort(rule))
+}
+
+// rulePort returns the port or port range of a firewall rule.
+// rule: The firewall rule configuration.
+func rulePort(rule *network.FirewallRule) string {
+	if rule.PortEnd != nil {
+		return fmt.Sprintf("%d-%d", *rule.Port, *rule.PortEnd)
+	}
+	return strconv.Itoa(*rule.Port)
+}
+
+// matchesInterface returns whether an interface name matches any of the interface names of a zone.
+// A trailing * matches any suffix, as in nftables.
+// interfaces: The interface names of the zone.
+// iface: The interface name.
+func matchesInterface(interfaces []string, iface string) bool {
+	for _, pattern := range interfaces {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(iface, prefix) {
+			return true
+		}
+		if pattern == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceRules returns the rules restricting a statement to the source IPs per address family.
+// sourceIPs: The source IPs; empty or all addresses do not restrict the statement.
+// statement: The statement to restrict.
+func sourceRules(sourceIPs []string, statement string) []string {
+	ipv4, ipv6 := splitFamilies(sourceIPs)
+	if len(sourceIPs) == 0 || (slices.Contains(ipv4, "0.0.0.0/0") && slices.Contains(ipv6, "::/0")) {
+		return []string{statement}
+	}
+
+	var rules []string
+	if len(ipv4) > 0 {
+		rules = append(rules, fmt.Sprintf("ip saddr { %s } %s", strings.Join(ipv4, ", "), statement))
+	}
+	if len(ipv6) > 0 {
+		rules = append(rules, fmt.Sprintf("ip6 saddr { %s } %s", strings.Join(ipv6, ", "), statement))
+	}
+	return rules
+}
+
+// forwardRules returns the forwarding rules between the zones.
+// Traffic between the filtered zones is dropped unless the zone pair is allowed.
+// forward: The allowed zone pairs.
+// zoneInterfaces: The interface names per filtered zone.
+func forwardRules(forward []*network.HostFirewallForwardConfig, zoneInterfaces map[string][]string) ([]string, error) {
+	var rules []string
+	for _, pair := range forward {
+		from := defaults.GetOrDefault(pair.From, "")
+		to := defaults.GetOrDefault(pair.To, "")
+		for _, zone := range []string{from, to} {
+			if _, ok := zoneInterfaces[zone]; !ok {
+				return nil, fmt.Errorf("%w: forward %s to %s: zone %q is not filtered", ErrInvalidHostFirewall, from, to,
+					zone)
+			}
+		}
+		rules = append(rules, interfaceRules(zoneInterfaces[from], zoneInterfaces[to], "accept")...)
+	}
+
+	var interfaces []string
+	for _, zone := range slices.Sorted(maps.Keys(zoneInterfaces)) {
+		interfaces = append(interfaces, zoneInterfaces[zone]...)
+	}
+	rules = append(rules, interfaceRules(interfaces, interfaces, "drop")...)
+	return rules, nil
+}
+
+// interfaceRules returns the rules applying a verdict to traffic between the interfaces.
+// from: The inbound interface names.
+// to: The outbound interface names.
+// verdict: The verdict to apply.
+func interfaceRules(from []string, to []string, verdict string) []string {
+	rules := make([]string, 0, len(from)*len(to))
+	for _, in := range from {
+		for _, out := range to {
+			rules = append(rules, fmt.Sprintf("iifname %q oifname %q %s", in, out, verdict))
+		}
+	}
+	return rules
+}
+
+// antiSpoofRules returns the rules dropping traffic from the publicly advertised BGP networks
+// arriving on the public interface or the GRE tunnels.
+// zoneInterfaces: The interface names per filtered zone.
+// bgpConfig: BGP configuration.
+func antiSpoofRules(zoneInterfaces map[string][]string, bgpConfig *bgp.Config) []string {
+	if bgpConfig.PublicNetworks == nil {
+		return nil
+	}
+
+	interfaces := slices.Concat(defaultInterfaces(zoneInterfaces, zonePublic), defaultInterfaces(zoneInterfaces, zoneGRE))
+	var rules []string
+	for _, iface := range interfaces {
+		if len(bgpConfig.PublicNetworks.IPv4) > 0 {
+			rules = append(rules, fmt.Sprintf("iifname %q ip saddr { %s } drop", iface,
+				strings.Join(bgpConfig.PublicNetworks.IPv4, ", ")))
+		}
+		if len(bgpConfig.PublicNetworks.IPv6) > 0 {
+			rules = append(rules, fmt.Sprintf("iifname %q ip6 saddr { %s } drop", iface,
+				strings.Join(bgpConfig.PublicNetworks.IPv6, ", ")))
+		}
+	}
+	return rules
+}
+
+// defaultInterfaces returns the interface names of a zone, whether it is filtered or not.
+// zoneInterfaces: The interface names per filtered zone.
+// zone: The zone.
+func defaultInterfaces(zoneInterfaces map[string][]string, zone string) []string {
+	if interfaces, ok := zoneInterfaces[zone]; ok {
+		return interfaces
+	}
+	return defaultZoneInterfaces[zone]
+}
+
+// splitFamilies splits IP addresses and prefixes into IPv4 and IPv6.
+// ips: The IP addresses and prefixes.
+func splitFamilies(ips []string) ([]string, []string) {
+	var ipv4, ipv6 []string
+	for _, ip := range ips {
+		addr, err := netip.ParseAddr(strings.Split(ip, "/")[0])
+		if err == nil && addr.Is4() {
+			ipv4 = append(ipv4, ip)
+		} else {
+			ipv6 = append(ipv6, ip)
+		}
+	}
+	return ipv4, ipv6
+}
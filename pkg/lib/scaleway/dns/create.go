@@ -0,0 +1,41 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumiverse/pulumi-scaleway/sdk/go/scaleway/domain"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/dns"
+)
+
+// CreateRecord creates the records in Scaleway Domains, one per value.
+// ctx: The Pulumi context for resource creation.
+// record: The record to create.
+// projectID: The Scaleway project of the DNS zone.
+func CreateRecord(ctx *pulumi.Context, record *dns.Record, projectID *string) ([]pulumi.Resource, error) {
+	zone := strings.TrimSuffix(record.Zone, ".")
+	name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(record.Domain, "."), zone), ".")
+
+	resources := make([]pulumi.Resource, 0, len(record.Values))
+	for i, value := range record.Values {
+		rec, err := domain.NewRecord(
+			ctx,
+			fmt.Sprintf("scw-dns-record-%s-%s-%d", record.Name, strings.ToLower(record.Type), i),
+			&domain.RecordArgs{
+				DnsZone:   pulumi.String(zone),
+				Name:      pulumi.String(name),
+				Type:      pulumi.String(record.Type),
+				Data:      value,
+				Ttl:       pulumi.Int(record.TTL),
+				ProjectId: pulumi.StringPtrFromPtr(projectID),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, rec)
+	}
+	return resources, nil
+}
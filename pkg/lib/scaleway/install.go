@@ -17,6 +17,7 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // application: The Scaleway application containing the credentials to be installed on the server.
 // scalewayConfig: Configuration for Scaleway, including project information.
 // dependsOn: Pulumi resource option to specify dependencies.
@@ -24,15 +25,12 @@ func Install(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	application *application.Application,
 	scalewayConfig *scaleway.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -66,7 +64,7 @@ func Install(
 			return *hash
 		})
 	scalewayRcloneCopy := scalewayRcloneHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			"remote-copy-scaleway-rclone-conf",
 			&remote.CopyToRemoteArgs{
@@ -75,6 +73,7 @@ func Install(
 				Triggers:   pulumi.Array{scalewayRcloneHash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
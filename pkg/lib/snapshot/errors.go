@@ -0,0 +1,6 @@
+package snapshot
+
+import "errors"
+
+// ErrMissingToken is returned if scheduled snapshots are configured without a Hetzner Cloud API token.
+var ErrMissingToken = errors.New("snapshot: missing token")
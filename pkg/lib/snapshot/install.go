@@ -0,0 +1,56 @@
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	serverConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// defaultRetention is the default number of snapshots to keep.
+const defaultRetention = 7
+
+// Install the scheduled snapshots of the server via SSH.
+// Nothing is installed if no snapshots are configured.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// serverConfig: The server configuration.
+// token: The Hetzner Cloud API token to create and expire the snapshots with.
+// dependsOn: Pulumi resource option to specify dependencies.
+func Install(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	serverConfig *serverConf.Config,
+	token *pulumi.StringOutput,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) ([]pulumi.Output, error) {
+	if serverConfig.Snapshots == nil {
+		return nil, nil
+	}
+	if token == nil {
+		return nil, ErrMissingToken
+	}
+
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	tokenCopy := install.CopySecretToRemote(ctx, "snapshot-token", *token, "/opt/snapshot/token", conn, dependsOn)
+
+	cron, cErr := install.Cron(ctx, "snapshot", conn, map[string]any{
+		"name":      fmt.Sprintf("%s-%s", config.GlobalName, config.Environment),
+		"retention": defaults.GetOrDefault(serverConfig.Snapshots.Retention, defaultRetention),
+	}, dependsOn)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	return append(cron, tokenCopy), nil
+}
@@ -1,6 +1,8 @@
 package tailscale
 
 import (
+	"strings"
+
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
 	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
@@ -15,6 +17,7 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // tailscaleConfig: Configuration for Tailscale installation.
 // gcpConfig: GCP configuration.
 // dependsOn: Pulumi resource option to specify dependencies.
@@ -22,14 +25,11 @@ func Install(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	tailscaleConfig *tailscaleConf.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -39,7 +39,8 @@ func Install(
 	}
 
 	dockerCompose, dcErr := template.Render("./assets/tailscale/docker-compose.yml.j2", map[string]any{
-		"authKey": tailscaleConfig.AuthKey,
+		"authKey":          tailscaleConfig.AuthKey,
+		"advertisedRoutes": strings.Join(AdvertisedRoutes, ","),
 	})
 	if dcErr != nil {
 		return nil, dcErr
@@ -50,7 +51,7 @@ func Install(
 			return *hash
 		})
 	dockerComposeCopy := dockerComposeHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			"remote-copy-tailscale-docker-compose",
 			&remote.CopyToRemoteArgs{
@@ -59,11 +60,12 @@ func Install(
 				Triggers:   pulumi.Array{dockerComposeHash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
-	cronResources, cronErr := install.Cron(ctx, "tailscale", conn, opts...)
+	cronResources, cronErr := install.Cron(ctx, "tailscale", conn, nil, opts...)
 	if cronErr != nil {
 		return nil, cronErr
 	}
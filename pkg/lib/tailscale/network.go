@@ -0,0 +1,6 @@
+package tailscale
+
+// AdvertisedRoutes are the routes Tailscale advertises to the tailnet.
+//
+//nolint:gochecknoglobals // global is acceptable here
+var AdvertisedRoutes = []string{"10.0.0.0/8"}
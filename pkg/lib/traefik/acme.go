@@ -0,0 +1,153 @@
+package traefik
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/dns"
+)
+
+const (
+	// challengeDNS is the ACME DNS-01 challenge.
+	challengeDNS = "dns"
+	// challengeHTTP is the ACME HTTP-01 challenge.
+	challengeHTTP = "http"
+	// challengeTLSALPN is the ACME TLS-ALPN-01 challenge.
+	challengeTLSALPN = "tlsalpn"
+)
+
+const (
+	// providerGCloud is the Google Cloud DNS provider.
+	providerGCloud = "gcloud"
+	// providerScaleway is the Scaleway DNS provider.
+	providerScaleway = "scaleway"
+)
+
+// stagingCAServer is the directory of the Let's Encrypt staging CA.
+const stagingCAServer = "https://acme-staging-v02.api.letsencrypt.org/directory"
+
+// letsEncryptCAA is the CAA identifying domain of Let's Encrypt.
+const letsEncryptCAA = "letsencrypt.org"
+
+// CertificateAuthorities returns the certificate authorities Traefik requests certificates from.
+// Wildcard certificates are only allowed if wildcard certificates are configured.
+// traefikConfig: Traefik configuration.
+func CertificateAuthorities(traefikConfig *traefikConf.Config) *dns.CAA {
+	caa := &dns.CAA{
+		Issue: []string{letsEncryptCAA},
+	}
+	if traefikConfig.ACME != nil && len(traefikConfig.ACME.Wildcards) > 0 {
+		caa.IssueWild = []string{letsEncryptCAA}
+	}
+	return caa
+}
+
+// certResolvers returns the certificate resolvers to render the static configuration with.
+// acmeConfig: The ACME configuration.
+func certResolvers(acmeConfig *traefikConf.ACMEConfig) (map[string]map[string]any, error) {
+	if acmeConfig == nil {
+		acmeConfig = &traefikConf.ACMEConfig{}
+	}
+
+	configs := map[string]*traefikConf.CertResolverConfig{
+		defaultCertResolver: {},
+	}
+	maps.Copy(configs, acmeConfig.Resolvers)
+
+	// staging certificates are kept apart to not mix them up with production certificates
+	var caServer string
+	storage := "/etc/certs/acme.json"
+	if defaults.GetOrDefault(acmeConfig.Staging, false) {
+		caServer = stagingCAServer
+		storage = "/etc/certs/acme-staging.json"
+	}
+
+	resolvers := make(map[string]map[string]any, len(configs))
+	for name, resolver := range configs {
+		if resolver == nil {
+			resolver = &traefikConf.CertResolverConfig{}
+		}
+
+		challenge := defaults.GetOrDefault(resolver.Challenge, challengeDNS)
+		var provider string
+		switch challenge {
+		case challengeDNS:
+			provider = defaults.GetOrDefault(resolver.Provider, providerGCloud)
+			if provider != providerGCloud && provider != providerScaleway {
+				return nil, fmt.Errorf("%w: %s: unknown DNS provider %q", ErrInvalidCertResolver, name, provider)
+			}
+		case challengeHTTP, challengeTLSALPN:
+		default:
+			return nil, fmt.Errorf("%w: %s: unknown challenge %q", ErrInvalidCertResolver, name, challenge)
+		}
+
+		resolvers[name] = map[string]any{
+			"challenge": challenge,
+			"provider":  provider,
+			"keyType":   defaults.GetOrDefault(resolver.KeyType, "EC256"),
+			"caServer":  caServer,
+			"storage":   storage,
+		}
+	}
+	return resolvers, nil
+}
+
+// usesDNSProvider returns whether any certificate resolver uses the DNS provider.
+// resolvers: The certificate resolvers.
+// provider: The DNS provider.
+func usesDNSProvider(resolvers map[string]map[string]any, provider string) bool {
+	for _, resolver := range resolvers {
+		if resolver["provider"] == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardRoutes returns the routers requesting the wildcard certificates.
+// The routers have the lowest priority and route to the noop service to not shadow other routers.
+// acmeConfig: The ACME configuration.
+// resolvers: The certificate resolvers.
+func wildcardRoutes(
+	acmeConfig *traefikConf.ACMEConfig,
+	resolvers map[string]map[string]any,
+) (map[string]map[string]any, error) {
+	if acmeConfig == nil {
+		return nil, nil
+	}
+
+	routes := make(map[string]map[string]any, len(acmeConfig.Wildcards))
+	for name, wildcard := range acmeConfig.Wildcards {
+		if wildcard.Domain == nil {
+			return nil, fmt.Errorf("%w: wildcard %s: missing domain", ErrInvalidCertResolver, name)
+		}
+
+		resolverName := defaults.GetOrDefault(wildcard.Resolver, defaultCertResolver)
+		resolver, ok := resolvers[resolverName]
+		if !ok {
+			return nil, fmt.Errorf("%w: wildcard %s: unknown resolver %q", ErrInvalidCertResolver, name, resolverName)
+		}
+		if resolver["challenge"] != challengeDNS {
+			return nil, fmt.Errorf("%w: wildcard %s: resolver %q does not use the DNS challenge",
+				ErrInvalidCertResolver, name, resolverName)
+		}
+
+		routes[fmt.Sprintf("acme-wildcard-%s", name)] = map[string]any{
+			"rule":         fmt.Sprintf("Host(`%s`)", *wildcard.Domain),
+			"service":      "noop@internal",
+			"entryPoints":  []string{"websecure"},
+			"priority":     1,
+			"certResolver": resolverName,
+			"domains": []map[string]any{
+				{
+					"main": *wildcard.Domain,
+					"sans": []string{fmt.Sprintf("*.%s", *wildcard.Domain)},
+				},
+			},
+		}
+	}
+	return routes, nil
+}
@@ -0,0 +1,299 @@
+package traefik
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+)
+
+// defaultCertResolver is the default certificate resolver of routes.
+const defaultCertResolver = "letsencrypt"
+
+// ssoAddress is the address of the single sign-on proxy used by forward-auth middlewares.
+const ssoAddress = "http://oauth2-proxy:4180/"
+
+// dynamicTemplateData returns the data to render the dynamic (file provider) configuration with.
+// traefikConfig: Traefik configuration.
+// dnsConfig: DNS configuration.
+// networkConfig: Network configuration.
+// basicAuthUsers: The basic-auth users ("user:hash") per middleware.
+func dynamicTemplateData(
+	traefikConfig *traefikConf.Config,
+	dnsConfig *dns.Config,
+	networkConfig *network.Config,
+	basicAuthUsers map[string][]string,
+) (map[string]any, error) {
+	resolvers, rErr := certResolvers(traefikConfig.ACME)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	routes := make(map[string]map[string]any, len(traefikConfig.Routes))
+	for name, route := range traefikConfig.Routes {
+		entryPoints := route.EntryPoints
+		if len(entryPoints) == 0 {
+			entryPoints = []string{"websecure"}
+		}
+
+		var priority any
+		if route.Priority != nil {
+			priority = *route.Priority
+		}
+
+		certResolver := defaultCertResolver
+		var tlsOptions *string
+		if route.TLS != nil {
+			certResolver = defaults.GetOrDefault(route.TLS.CertResolver, defaultCertResolver)
+			tlsOptions = route.TLS.Options
+		}
+		if _, ok := resolvers[certResolver]; !ok {
+			return nil, fmt.Errorf("%w: route %s: unknown resolver %q", ErrInvalidCertResolver, name, certResolver)
+		}
+
+		if uErr := validateURLs(name, route.URLs); uErr != nil {
+			return nil, uErr
+		}
+
+		rule, ruErr := routeRule(name, route, dnsConfig)
+		if ruErr != nil {
+			return nil, ruErr
+		}
+
+		routes[name] = map[string]any{
+			"rule":               rule,
+			"service":            name,
+			"entryPoints":        entryPoints,
+			"priority":           priority,
+			"middlewares":        route.Middlewares,
+			"certResolver":       certResolver,
+			"tlsOptions":         tlsOptions,
+			"urls":               route.URLs,
+			"passHostHeader":     defaults.GetOrDefault(route.PassHostHeader, true),
+			"insecureSkipVerify": defaults.GetOrDefault(route.InsecureSkipVerify, false),
+		}
+	}
+	services := maps.Clone(routes)
+
+	middlewares := make(map[string]map[string]any, len(traefikConfig.Middlewares))
+	for name, middleware := range traefikConfig.Middlewares {
+		data, mErr := middlewareTemplateData(name, middleware, traefikConfig, networkConfig, basicAuthUsers)
+		if mErr != nil {
+			return nil, mErr
+		}
+		middlewares[name] = data
+	}
+	if traefikConfig.EntryPoints != nil && traefikConfig.EntryPoints.HSTS != nil {
+		if _, ok := middlewares[hstsMiddlewareName]; ok {
+			return nil, fmt.Errorf("%w: %s: reserved for the entrypoint HSTS policy", ErrInvalidMiddleware,
+				hstsMiddlewareName)
+		}
+		middlewares[hstsMiddlewareName] = hstsTemplateData(traefikConfig.EntryPoints.HSTS)
+	}
+
+	internal, internalMiddleware, iErr := internalRoutes(traefikConfig)
+	if iErr != nil {
+		return nil, iErr
+	}
+	for name, route := range internal {
+		if _, ok := routes[name]; ok {
+			return nil, fmt.Errorf("%w: %s: reserved for the internal entrypoint", ErrInvalidInternalEntryPoint, name)
+		}
+		routes[name] = route
+	}
+
+	wildcards, wErr := wildcardRoutes(traefikConfig.ACME, resolvers)
+	if wErr != nil {
+		return nil, wErr
+	}
+	for name, route := range wildcards {
+		if _, ok := routes[name]; ok {
+			return nil, fmt.Errorf("%w: %s: reserved for the wildcard certificates", ErrInvalidCertResolver, name)
+		}
+		routes[name] = route
+	}
+	if internalMiddleware != nil {
+		if _, ok := middlewares[internalSSOMiddlewareName]; ok {
+			return nil, fmt.Errorf("%w: %s: reserved for the internal entrypoint", ErrInvalidMiddleware,
+				internalSSOMiddlewareName)
+		}
+		middlewares[internalSSOMiddlewareName] = internalMiddleware
+	}
+
+	if _, ok := traefikConfig.TLSOptions[defaultTLSOptionsName]; ok {
+		return nil, fmt.Errorf("%w: %s: configure the default TLS options via entryPoints.tls", ErrInvalidTLSOptions,
+			defaultTLSOptionsName)
+	}
+	tlsOptions := maps.Clone(traefikConfig.TLSOptions)
+	if tlsOptions == nil {
+		tlsOptions = map[string]*traefikConf.TLSOptionConfig{}
+	}
+	tlsOptions[defaultTLSOptionsName] = defaultTLSOptions(traefikConfig.EntryPoints)
+
+	return map[string]any{
+		"routes":      routes,
+		"services":    services,
+		"middlewares": middlewares,
+		"tlsOptions":  tlsOptions,
+	}, nil
+}
+
+// validateURLs validates the upstream URLs of a route.
+// name: The name of the route.
+// urls: The upstream URLs.
+func validateURLs(name string, urls []string) error {
+	if len(urls) == 0 {
+		return fmt.Errorf("%w: route %s: missing upstream URLs", ErrInvalidRoute, name)
+	}
+	for _, u := range urls {
+		parsed, pErr := url.Parse(u)
+		if pErr != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("%w: route %s: invalid upstream URL %q", ErrInvalidRoute, name, u)
+		}
+	}
+	return nil
+}
+
+// routeRule returns the rule of a route, defaulting to the domains of the DNS entries serving the route.
+// name: The name of the route.
+// route: The route configuration.
+// dnsConfig: DNS configuration.
+func routeRule(name string, route *traefikConf.RouteConfig, dnsConfig *dns.Config) (string, error) {
+	if route.Rule != nil {
+		return *route.Rule, nil
+	}
+
+	var hosts []string
+	for _, entryName := range slices.Sorted(maps.Keys(dnsConfig.Entries)) {
+		entry := dnsConfig.Entries[entryName]
+		if entry.Service != nil && *entry.Service == name && entry.Domain != nil {
+			hosts = append(hosts, fmt.Sprintf("Host(`%s`)", *entry.Domain))
+		}
+	}
+	if len(hosts) == 0 {
+		return "", fmt.Errorf("%w: route %s: missing rule and no DNS entry serves the route", ErrInvalidRoute, name)
+	}
+	return strings.Join(hosts, " || "), nil
+}
+
+// middlewareTemplateData returns the data to render a middleware with.
+// name: The name of the middleware.
+// middleware: The middleware configuration.
+// traefikConfig: Traefik configuration.
+// networkConfig: Network configuration.
+// basicAuthUsers: The basic-auth users ("user:hash") per middleware.
+func middlewareTemplateData(
+	name string,
+	middleware *traefikConf.MiddlewareConfig,
+	traefikConfig *traefikConf.Config,
+	networkConfig *network.Config,
+	basicAuthUsers map[string][]string,
+) (map[string]any, error) {
+	types := 0
+	data := map[string]any{}
+
+	if middleware.ForwardAuth != nil {
+		if traefikConfig.SSO == nil {
+			return nil, ErrMissingSSO
+		}
+		types++
+		data["forwardAuth"] = map[string]any{
+			"address":             ssoAddress,
+			"authResponseHeaders": middleware.ForwardAuth.AuthResponseHeaders,
+		}
+	}
+
+	if middleware.IPAllowList != nil {
+		types++
+		sourceRanges := middleware.IPAllowList.SourceRanges
+		for _, rule := range middleware.IPAllowList.FirewallRules {
+			firewallRule, ok := networkConfig.FirewallRules[rule]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s: unknown firewall rule %q", ErrInvalidMiddleware, name, rule)
+			}
+			if defaults.GetOrDefault(firewallRule.Direction, "in") != "in" {
+				return nil, fmt.Errorf("%w: %s: firewall rule %q is not an inbound rule", ErrInvalidMiddleware, name, rule)
+			}
+			sourceRanges = append(sourceRanges, firewallRule.SourceIPs...)
+		}
+		data["ipAllowList"] = map[string]any{
+			"sourceRanges": sourceRanges,
+		}
+	}
+
+	if middleware.RateLimit != nil {
+		types++
+		data["rateLimit"] = rateLimitOptions(middleware.RateLimit)
+	}
+
+	if middleware.Headers != nil {
+		types++
+		data["headers"] = map[string]any{
+			"options":               headersOptions(middleware.Headers),
+			"customResponseHeaders": middleware.Headers.CustomResponseHeaders,
+		}
+	}
+
+	if middleware.BasicAuth != nil {
+		types++
+		data["basicAuth"] = map[string]any{
+			"users": basicAuthUsers[name],
+		}
+	}
+
+	if types != 1 {
+		return nil, fmt.Errorf("%w: %s: exactly one middleware type must be set", ErrInvalidMiddleware, name)
+	}
+	return data, nil
+}
+
+// rateLimitOptions returns the options of a rate limit middleware as YAML scalars.
+// rateLimit: The rate limit configuration.
+func rateLimitOptions(rateLimit *traefikConf.RateLimitConfig) map[string]string {
+	options := map[string]string{
+		"average": strconv.Itoa(defaults.GetOrDefault(rateLimit.Average, 0)),
+	}
+	if rateLimit.Burst != nil {
+		options["burst"] = strconv.Itoa(*rateLimit.Burst)
+	}
+	if rateLimit.Period != nil {
+		options["period"] = strconv.Quote(*rateLimit.Period)
+	}
+	return options
+}
+
+// headersOptions returns the options of a headers middleware as YAML scalars.
+// headers: The headers configuration.
+func headersOptions(headers *traefikConf.HeadersConfig) map[string]string {
+	options := map[string]string{}
+	if headers.STSSeconds != nil {
+		options["stsSeconds"] = strconv.Itoa(*headers.STSSeconds)
+	}
+	for key, value := range map[string]*bool{
+		"stsIncludeSubdomains": headers.STSIncludeSubdomains,
+		"stsPreload":           headers.STSPreload,
+		"frameDeny":            headers.FrameDeny,
+		"contentTypeNosniff":   headers.ContentTypeNosniff,
+	} {
+		if value != nil {
+			options[key] = strconv.FormatBool(*value)
+		}
+	}
+	for key, value := range map[string]*string{
+		"referrerPolicy":        headers.ReferrerPolicy,
+		"contentSecurityPolicy": headers.ContentSecurityPolicy,
+	} {
+		if value != nil {
+			options[key] = strconv.Quote(*value)
+		}
+	}
+	return options
+}
@@ -0,0 +1,110 @@
+package traefik
+
+import (
+	"strconv"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+)
+
+// defaultTLSOptionsName is the name of the TLS options Traefik applies to routers without explicit TLS options.
+const defaultTLSOptionsName = "default"
+
+// hstsMiddlewareName is the name of the middleware setting the Strict-Transport-Security header.
+const hstsMiddlewareName = "hsts"
+
+// defaultHSTSSeconds is the default max-age of the Strict-Transport-Security header.
+const defaultHSTSSeconds = 31536000
+
+// defaultTrustedIPs are the IPs trusted to send the PROXY protocol header by default.
+//
+//nolint:gochecknoglobals // default values
+var defaultTrustedIPs = []string{
+	"127.0.0.0/8",
+	"::1",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+}
+
+// defaultCipherSuites are the cipher suites allowed for TLS 1.2 by default.
+//
+//nolint:gochecknoglobals // default values
+var defaultCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+}
+
+// defaultCurvePreferences are the elliptic curves in preference order by default.
+//
+//nolint:gochecknoglobals // default values
+var defaultCurvePreferences = []string{
+	"X25519",
+	"CurveP256",
+	"CurveP384",
+}
+
+// staticTemplateData returns the data to render the entrypoints of the static configuration with.
+// entryPoints: The entrypoint policy.
+func staticTemplateData(entryPoints *traefikConf.EntryPointsConfig) map[string]any {
+	if entryPoints == nil {
+		entryPoints = &traefikConf.EntryPointsConfig{}
+	}
+
+	trustedIPs := entryPoints.TrustedIPs
+	if len(trustedIPs) == 0 {
+		trustedIPs = defaultTrustedIPs
+	}
+
+	return map[string]any{
+		"redirect":   defaults.GetOrDefault(entryPoints.Redirect, false),
+		"trustedIPs": trustedIPs,
+		"hsts":       entryPoints.HSTS != nil,
+	}
+}
+
+// defaultTLSOptions returns the hardened default TLS options of the entrypoints.
+// entryPoints: The entrypoint policy.
+func defaultTLSOptions(entryPoints *traefikConf.EntryPointsConfig) *traefikConf.TLSOptionConfig {
+	tlsConfig := &traefikConf.TLSOptionConfig{}
+	if entryPoints != nil && entryPoints.TLS != nil {
+		tlsConfig = entryPoints.TLS
+	}
+
+	cipherSuites := tlsConfig.CipherSuites
+	if len(cipherSuites) == 0 {
+		cipherSuites = defaultCipherSuites
+	}
+	curvePreferences := tlsConfig.CurvePreferences
+	if len(curvePreferences) == 0 {
+		curvePreferences = defaultCurvePreferences
+	}
+	minVersion := defaults.GetOrDefault(tlsConfig.MinVersion, "VersionTLS12")
+	sniStrict := defaults.GetOrDefault(tlsConfig.SniStrict, true)
+
+	return &traefikConf.TLSOptionConfig{
+		MinVersion:       &minVersion,
+		MaxVersion:       tlsConfig.MaxVersion,
+		CipherSuites:     cipherSuites,
+		CurvePreferences: curvePreferences,
+		SniStrict:        &sniStrict,
+	}
+}
+
+// hstsTemplateData returns the data to render the HSTS headers middleware with.
+// hsts: The HSTS configuration.
+func hstsTemplateData(hsts *traefikConf.HSTSConfig) map[string]any {
+	return map[string]any{
+		"headers": map[string]any{
+			"options": map[string]string{
+				"stsSeconds":           strconv.Itoa(defaults.GetOrDefault(hsts.Seconds, defaultHSTSSeconds)),
+				"stsIncludeSubdomains": strconv.FormatBool(defaults.GetOrDefault(hsts.IncludeSubdomains, true)),
+				"stsPreload":           strconv.FormatBool(defaults.GetOrDefault(hsts.Preload, false)),
+			},
+		},
+	}
+}
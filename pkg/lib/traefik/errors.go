@@ -0,0 +1,18 @@
+package traefik
+
+import "errors"
+
+var (
+	// ErrInvalidCertResolver is returned if a certificate resolver or wildcard certificate configuration is invalid.
+	ErrInvalidCertResolver = errors.New("traefik: invalid certificate resolver")
+	// ErrInvalidInternalEntryPoint is returned if the internal entrypoint configuration is invalid.
+	ErrInvalidInternalEntryPoint = errors.New("traefik: invalid internal entrypoint")
+	// ErrInvalidMiddleware is returned if a middleware configuration is invalid.
+	ErrInvalidMiddleware = errors.New("traefik: invalid middleware")
+	// ErrInvalidRoute is returned if a route configuration is invalid.
+	ErrInvalidRoute = errors.New("traefik: invalid route")
+	// ErrInvalidTLSOptions is returned if the TLS options conflict with the entrypoint policy.
+	ErrInvalidTLSOptions = errors.New("traefik: invalid TLS options")
+	// ErrMissingSSO is returned if a forward-auth middleware is configured without the single sign-on proxy.
+	ErrMissingSSO = errors.New("traefik: forward-auth requires the sso configuration and its OIDC client")
+)
@@ -1,12 +1,24 @@
 package traefik
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/model/scaleway/iam/application"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
 	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/scaleway"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+	traefikData "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/traefik"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
 )
 
@@ -14,20 +26,30 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// traefikData: Traefik configuration data.
+// application: The Scaleway application containing the credentials of the Scaleway DNS provider.
+// scalewayConfig: Scaleway configuration.
 // dnsConfig: DNS configuration.
+// serverConfig: Server configuration.
+// networkConfig: Network configuration.
+// traefikConfig: Traefik configuration.
 // dependsOn: Pulumi resource option to specify dependencies.
-func Install(
+func installer(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	traefikData *traefikData.Data,
+	application *application.Application,
+	scalewayConfig *scaleway.Config,
 	dnsConfig *dns.Config,
+	serverConfig *server.Config,
+	networkConfig *network.Config,
+	traefikConfig *traefikConf.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -36,8 +58,18 @@ func Install(
 		return nil, prepErr
 	}
 
+	internal := internalTemplateData(traefikConfig.Internal, *serverConfig.IPv4)
+	resolvers, rErr := certResolvers(traefikConfig.ACME)
+	if rErr != nil {
+		return nil, rErr
+	}
+	useScaleway := usesDNSProvider(resolvers, providerScaleway)
+
 	dockerCompose, dcErr := template.Render("./assets/traefik/docker-compose.yml.j2", map[string]any{
 		"gcpProject": dnsConfig.Project,
+		"sso":        traefikConfig.SSO,
+		"internal":   internal,
+		"scaleway":   useScaleway,
 	})
 	if dcErr != nil {
 		return nil, dcErr
@@ -48,7 +80,7 @@ func Install(
 			return *hash
 		})
 	dockerComposeCopy := dockerComposeHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			"remote-copy-traefik-docker-compose",
 			&remote.CopyToRemoteArgs{
@@ -57,13 +89,17 @@ func Install(
 				Triggers:   pulumi.Array{dockerComposeHash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
-	traefikYaml, dcErr := template.Render("./assets/traefik/traefik.yml.j2", map[string]any{
-		"acmeEmail": dnsConfig.Email,
-	})
+	traefikYmlData := staticTemplateData(traefikConfig.EntryPoints)
+	traefikYmlData["acmeEmail"] = dnsConfig.Email
+	traefikYmlData["certResolvers"] = resolvers
+	traefikYmlData["internal"] = internal
+	traefikYmlData["accessLog"] = traefikConfig.AccessLog != nil
+	traefikYaml, dcErr := template.Render("./assets/traefik/traefik.yml.j2", traefikYmlData)
 	if dcErr != nil {
 		return nil, dcErr
 	}
@@ -73,28 +109,204 @@ func Install(
 			return *hash
 		})
 	traefikYmlCopy := traefikYmlHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(ctx, "remote-copy-traefik-config", &remote.CopyToRemoteArgs{
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-traefik-config", &remote.CopyToRemoteArgs{
 			Source:     pulumi.NewFileAsset("./outputs/traefik_traefik.yml"),
 			RemotePath: pulumi.String("/opt/traefik/traefik.yml"),
 			Triggers:   pulumi.Array{traefikYmlHash},
 			Connection: conn,
-		}, opts...)
+		}, 0o644, opts...)
+		return pulumi.DependsOn([]pulumi.Resource{cmd})
+	})
+
+	// validate the dynamic configuration before rendering it with the generated credentials
+	if _, dyErr := dynamicTemplateData(traefikConfig, dnsConfig, networkConfig, nil); dyErr != nil {
+		return nil, dyErr
+	}
+	dynamicYaml, _ := basicAuthUsers(traefikData).ApplyT(func(users map[string][]string) string {
+		data, _ := dynamicTemplateData(traefikConfig, dnsConfig, networkConfig, users)
+		tpl, _ := template.Render("./assets/traefik/dynamic.yml.j2", data)
+		return tpl
+	}).(pulumi.StringOutput)
+	dynamicYmlHash := file.WritePulumi("./outputs/traefik_dynamic.yml", dynamicYaml).
+		ApplyT(func(_ string) string {
+			hash, _ := file.Hash("./outputs/traefik_dynamic.yml")
+			return *hash
+		})
+	dynamicYmlCopy := dynamicYmlHash.ApplyT(func(_ string) pulumi.ResourceOption {
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-traefik-dynamic-config", &remote.CopyToRemoteArgs{
+			Source:     pulumi.NewFileAsset("./outputs/traefik_dynamic.yml"),
+			RemotePath: pulumi.String("/opt/traefik/dynamic/routes.yml"),
+			Triggers:   pulumi.Array{dynamicYmlHash},
+			Connection: conn,
+		}, 0o644, opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
+	ssoResources, ssoHashes := createSSOConfig(ctx, traefikData, traefikConfig, conn, opts...)
+	var acmeResources []pulumi.Output
+	var acmeHashes pulumi.Array
+	if useScaleway {
+		acmeResources, acmeHashes = createACMEConfig(ctx, application, scalewayConfig, conn, opts...)
+	}
+
+	cronResources, cronErr := install.Cron(ctx, "traefik", conn, map[string]any{
+		"accessLog":     traefikConfig.AccessLog != nil,
+		"retentionDays": accessLogRetentionDays(traefikConfig.AccessLog),
+	}, opts...)
+	if cronErr != nil {
+		return nil, cronErr
+	}
+
 	opts, systemdServiceHash, shErr := install.SystemDService(ctx, "traefik", conn, opts...)
 	if shErr != nil {
 		return nil, shErr
 	}
 
-	installFn, iErr := file.ReadContents("./assets/traefik/install.sh")
+	installFn, iErr := template.Render("./assets/traefik/install.sh.j2", map[string]any{
+		"bucket": map[string]string{
+			"id":   config.BackupBucketID,
+			"path": config.BackupBucketPath,
+		},
+	})
 	if iErr != nil {
 		return nil, iErr
 	}
+	triggers := append(
+		append(ssoHashes, acmeHashes...),
+		dockerComposeHash,
+		pulumi.String(*systemdServiceHash),
+		traefikYmlHash,
+	)
 	return remote.NewCommand(ctx, "remote-command-install-traefik", &remote.CommandArgs{
 		Create:     pulumi.StringPtr(installFn),
 		Update:     pulumi.StringPtr(installFn),
-		Triggers:   pulumi.Array{dockerComposeHash, pulumi.String(*systemdServiceHash), traefikYmlHash},
+		Triggers:   triggers,
 		Connection: conn,
-	}, append(opts, install.CollectResourceOptions([]pulumi.Output{dockerComposeCopy, traefikYmlCopy})...)...)
+	}, append(
+		opts,
+		install.CollectResourceOptions(
+			append(
+				slices.Concat(ssoResources, acmeResources, cronResources),
+				dockerComposeCopy,
+				traefikYmlCopy,
+				dynamicYmlCopy,
+			),
+		)...,
+	)...)
+}
+
+// basicAuthUsers returns the basic-auth users ("user:hash") per middleware.
+// traefikData: Traefik configuration data.
+func basicAuthUsers(traefikData *traefikData.Data) pulumi.AnyOutput {
+	names := slices.Sorted(maps.Keys(traefikData.BasicAuth))
+	hashes := make([]any, 0, len(names))
+	for _, name := range names {
+		hashes = append(hashes, traefikData.BasicAuth[name].Hashes)
+	}
+
+	return pulumi.All(hashes...).ApplyT(func(args []any) map[string][]string {
+		users := make(map[string][]string, len(names))
+		for i, name := range names {
+			userHashes, _ := args[i].(map[string]string)
+			for _, user := range slices.Sorted(maps.Keys(userHashes)) {
+				users[name] = append(users[name], fmt.Sprintf("%s:%s", user, userHashes[user]))
+			}
+		}
+		return users
+	}).(pulumi.AnyOutput)
+}
+
+// createSSOConfig generates the single sign-on proxy (oauth2-proxy) environment file and uploads it.
+// ctx: Pulumi context.
+// traefikData: Traefik configuration data.
+// traefikConfig: Traefik configuration.
+// conn: The remote connection arguments.
+// opts: Additional Pulumi resource options.
+func createSSOConfig(
+	ctx *pulumi.Context,
+	traefikData *traefikData.Data,
+	traefikConfig *traefikConf.Config,
+	conn *remote.ConnectionArgs,
+	opts ...pulumi.ResourceOption,
+) ([]pulumi.Output, pulumi.Array) {
+	if traefikData.SSO == nil {
+		return nil, nil
+	}
+
+	emailDomains := traefikConfig.SSO.EmailDomains
+	if len(emailDomains) == 0 {
+		emailDomains = []string{"*"}
+	}
+
+	ssoEnv, _ := traefikData.SSO.CookieSecret.ApplyT(func(cookieSecret string) string {
+		tpl, _ := template.Render("./assets/traefik/oauth2-proxy.env.j2", map[string]any{
+			"domain":       traefikConfig.SSO.Domain,
+			"cookieDomain": traefikConfig.SSO.CookieDomain,
+			"emailDomains": strings.Join(emailDomains, ","),
+			"cookieSecret": cookieSecret,
+			"oidc": map[string]string{
+				"issuerUrl":    traefikData.SSO.IssuerURL,
+				"clientId":     traefikData.SSO.ClientID,
+				"clientSecret": traefikData.SSO.ClientSecret,
+			},
+		})
+		return tpl
+	}).(pulumi.StringOutput)
+	ssoEnvHash := file.WritePulumi("./outputs/traefik_oauth2-proxy.env", ssoEnv).
+		ApplyT(func(_ string) string {
+			hash, _ := file.Hash("./outputs/traefik_oauth2-proxy.env")
+			return *hash
+		})
+	ssoEnvCopy := ssoEnvHash.ApplyT(func(_ string) pulumi.ResourceOption {
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-traefik-oauth2-proxy-env", &remote.CopyToRemoteArgs{
+			Source:     pulumi.NewFileAsset("./outputs/traefik_oauth2-proxy.env"),
+			RemotePath: pulumi.String("/opt/traefik/oauth2-proxy.env"),
+			Triggers:   pulumi.Array{ssoEnvHash},
+			Connection: conn,
+		}, 0o600, opts...)
+		return pulumi.DependsOn([]pulumi.Resource{cmd})
+	})
+
+	return []pulumi.Output{ssoEnvCopy}, pulumi.Array{ssoEnvHash}
+}
+
+// createACMEConfig generates the credentials of the Scaleway DNS provider and uploads them to the remote server.
+// ctx: Pulumi context.
+// application: The Scaleway application containing the credentials.
+// scalewayConfig: Scaleway configuration.
+// conn: The remote connection arguments.
+// opts: Additional Pulumi resource options.
+func createACMEConfig(
+	ctx *pulumi.Context,
+	application *application.Application,
+	scalewayConfig *scaleway.Config,
+	conn *remote.ConnectionArgs,
+	opts ...pulumi.ResourceOption,
+) ([]pulumi.Output, pulumi.Array) {
+	acmeEnv, _ := pulumi.All(application.Key.AccessKey, application.Key.SecretKey).ApplyT(func(args []any) string {
+		accessKey, _ := args[0].(string)
+		secretKey, _ := args[1].(string)
+		tpl, _ := template.Render("./assets/traefik/acme.env.j2", map[string]any{
+			"accessKey": accessKey,
+			"secretKey": secretKey,
+			"projectId": scalewayConfig.DNSProject,
+		})
+		return tpl
+	}).(pulumi.StringOutput)
+	acmeEnvHash := file.WritePulumi("./outputs/traefik_acme.env", acmeEnv).
+		ApplyT(func(_ string) string {
+			hash, _ := file.Hash("./outputs/traefik_acme.env")
+			return *hash
+		})
+	acmeEnvCopy := acmeEnvHash.ApplyT(func(_ string) pulumi.ResourceOption {
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-traefik-acme-env", &remote.CopyToRemoteArgs{
+			Source:     pulumi.NewFileAsset("./outputs/traefik_acme.env"),
+			RemotePath: pulumi.String("/opt/traefik/acme.env"),
+			Triggers:   pulumi.Array{acmeEnvHash},
+			Connection: conn,
+		}, 0o600, opts...)
+		return pulumi.DependsOn([]pulumi.Resource{cmd})
+	})
+
+	return []pulumi.Output{acmeEnvCopy}, pulumi.Array{acmeEnvHash}
 }
@@ -0,0 +1,93 @@
+package traefik
+
+import (
+	"fmt"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+)
+
+// internalEntryPoint is the name of the entrypoint serving the dashboard and metrics.
+const internalEntryPoint = "internal"
+
+// defaultInternalPort is the default port of the internal entrypoint.
+const defaultInternalPort = 8080
+
+// internalSSOMiddlewareName is the name of the forward-auth middleware protecting the internal entrypoint.
+const internalSSOMiddlewareName = "internal-sso"
+
+// defaultAccessLogRetentionDays is the default number of days access logs are retained in the backup bucket.
+const defaultAccessLogRetentionDays = 30
+
+// internalEnabled returns whether the internal entrypoint serves the dashboard or metrics.
+// internalConfig: The internal entrypoint configuration.
+func internalEnabled(internalConfig *traefikConf.InternalConfig) bool {
+	return internalConfig != nil &&
+		(defaults.GetOrDefault(internalConfig.Dashboard, false) || defaults.GetOrDefault(internalConfig.Metrics, false))
+}
+
+// internalTemplateData returns the data to render the internal entrypoint of the static configuration
+// and the Docker Compose file with.
+// internalConfig: The internal entrypoint configuration.
+// privateIPv4: The private IPv4 address of the server.
+func internalTemplateData(internalConfig *traefikConf.InternalConfig, privateIPv4 string) map[string]any {
+	if !internalEnabled(internalConfig) {
+		return nil
+	}
+
+	return map[string]any{
+		"address":   defaults.GetOrDefault(internalConfig.Address, privateIPv4),
+		"port":      defaults.GetOrDefault(internalConfig.Port, defaultInternalPort),
+		"dashboard": defaults.GetOrDefault(internalConfig.Dashboard, false),
+		"metrics":   defaults.GetOrDefault(internalConfig.Metrics, false),
+	}
+}
+
+// internalRoutes returns the routers of the dashboard and metrics, and the forward-auth middleware protecting them.
+// traefikConfig: Traefik configuration.
+func internalRoutes(traefikConfig *traefikConf.Config) (map[string]map[string]any, map[string]any, error) {
+	internalConfig := traefikConfig.Internal
+	if !internalEnabled(internalConfig) {
+		return nil, nil, nil
+	}
+	if traefikConfig.SSO == nil {
+		return nil, nil, ErrMissingSSO
+	}
+	if internalConfig.Domain == nil {
+		return nil, nil, fmt.Errorf("%w: missing domain", ErrInvalidInternalEntryPoint)
+	}
+
+	routes := map[string]map[string]any{}
+	route := func(rule string, service string) map[string]any {
+		return map[string]any{
+			"rule":         fmt.Sprintf("Host(`%s`) && (%s)", *internalConfig.Domain, rule),
+			"service":      service,
+			"entryPoints":  []string{internalEntryPoint},
+			"middlewares":  []string{internalSSOMiddlewareName},
+			"certResolver": defaultCertResolver,
+		}
+	}
+	if defaults.GetOrDefault(internalConfig.Dashboard, false) {
+		routes["internal-dashboard"] = route("PathPrefix(`/api`) || PathPrefix(`/dashboard`)", "api@internal")
+	}
+	if defaults.GetOrDefault(internalConfig.Metrics, false) {
+		routes["internal-metrics"] = route("PathPrefix(`/metrics`)", "prometheus@internal")
+	}
+
+	middleware := map[string]any{
+		"forwardAuth": map[string]any{
+			"address": ssoAddress,
+		},
+	}
+	return routes, middleware, nil
+}
+
+// accessLogRetentionDays returns the number of days access logs are retained in the backup bucket.
+// accessLogConfig: The access log configuration.
+func accessLogRetentionDays(accessLogConfig *traefikConf.AccessLogConfig) int {
+	if accessLogConfig == nil {
+		return defaultAccessLogRetentionDays
+	}
+	return defaults.GetOrDefault(accessLogConfig.RetentionDays, defaultAccessLogRetentionDays)
+}
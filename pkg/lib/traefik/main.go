@@ -0,0 +1,66 @@
+package traefik
+
+import (
+	"github.com/muhlba91/pulumi-shared-library/pkg/model/scaleway/iam/application"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/network"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/oidc"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/scaleway"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/traefik"
+)
+
+// Install Traefik on the remote server via SSH and create necessary resources.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// application: The Scaleway application containing the credentials of the Scaleway DNS provider.
+// scalewayConfig: Scaleway configuration.
+// dnsConfig: DNS configuration.
+// serverConfig: Server configuration.
+// oidcConfig: OIDC configuration.
+// networkConfig: Network configuration.
+// traefikConfig: Traefik configuration.
+// dependsOn: Pulumi resource option to specify dependencies.
+func Install(ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	application *application.Application,
+	scalewayConfig *scaleway.Config,
+	dnsConfig *dns.Config,
+	serverConfig *server.Config,
+	oidcConfig *oidc.Config,
+	networkConfig *network.Config,
+	traefikConfig *traefikConf.Config,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) (*traefik.Data, *remote.Command, error) {
+	traefikData, tdErr := createResources(ctx, oidcConfig, traefikConfig)
+	if tdErr != nil {
+		return nil, nil, tdErr
+	}
+	traefikInstall, tiErr := installer(
+		ctx,
+		sshIPv4,
+		privateKeyPem,
+		proxy,
+		traefikData,
+		application,
+		scalewayConfig,
+		dnsConfig,
+		serverConfig,
+		networkConfig,
+		traefikConfig,
+		dependsOn,
+	)
+	if tiErr != nil {
+		return nil, nil, tiErr
+	}
+
+	return traefikData, traefikInstall, nil
+}
@@ -0,0 +1,95 @@
+package traefik
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/lib/random"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	randomProv "github.com/pulumi/pulumi-random/sdk/v4/go/random"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/oidc"
+	traefikConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/traefik"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/traefik"
+)
+
+// Length of the generated Traefik secrets.
+const traefikSecretLength = 32
+
+// defaultSSOClient is the default name of the OIDC client used by the single sign-on proxy.
+const defaultSSOClient = "traefik"
+
+// CreateResources creates resources for Traefik based on the provided configuration.
+// ctx: The Pulumi context for resource creation.
+// oidcConfig: Configuration related to OIDC (OpenID Connect).
+// traefikConfig: Traefik configuration.
+func createResources(
+	ctx *pulumi.Context,
+	oidcConfig *oidc.Config,
+	traefikConfig *traefikConf.Config,
+) (*traefik.Data, error) {
+	data := &traefik.Data{
+		BasicAuth: map[string]*traefik.BasicAuth{},
+	}
+
+	if traefikConfig.SSO != nil {
+		client, ok := oidcConfig.Clients[defaults.GetOrDefault(traefikConfig.SSO.Client, defaultSSOClient)]
+		if !ok || oidcConfig.DiscoveryURL == nil {
+			return nil, ErrMissingSSO
+		}
+
+		cookieSecret, csErr := random.CreatePassword(
+			ctx,
+			fmt.Sprintf("password-traefik-sso-cookie-secret-%s", config.Environment),
+			&random.PasswordOptions{
+				Length:  traefikSecretLength,
+				Special: false,
+			},
+		)
+		if csErr != nil {
+			return nil, csErr
+		}
+
+		data.SSO = &traefik.SSO{
+			IssuerURL:    *oidcConfig.DiscoveryURL,
+			ClientID:     *client.ClientID,
+			ClientSecret: *client.ClientSecret,
+			CookieSecret: cookieSecret.Password,
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(traefikConfig.Middlewares)) {
+		middleware := traefikConfig.Middlewares[name]
+		if middleware.BasicAuth == nil {
+			continue
+		}
+
+		passwords := pulumi.StringMap{}
+		hashes := pulumi.StringMap{}
+		for _, user := range middleware.BasicAuth.Users {
+			password, pErr := randomProv.NewRandomPassword(
+				ctx,
+				fmt.Sprintf("password-traefik-basic-auth-%s-%s-%s", name, user, config.Environment),
+				&randomProv.RandomPasswordArgs{
+					Length:  pulumi.Int(traefikSecretLength),
+					Special: pulumi.Bool(false),
+				},
+			)
+			if pErr != nil {
+				return nil, pErr
+			}
+			passwords[user] = password.Result
+			hashes[user] = password.BcryptHash
+		}
+
+		data.BasicAuth[name] = &traefik.BasicAuth{
+			Passwords: passwords.ToStringMapOutput(),
+			Hashes:    hashes.ToStringMapOutput(),
+		}
+	}
+
+	return data, nil
+}
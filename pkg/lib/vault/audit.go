@@ -0,0 +1,90 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
+)
+
+// auditLogPath is the path of the file audit log inside the Vault container.
+const auditLogPath = "/vault/logs/audit.log"
+
+// defaultAuditRetentionDays is the default number of days audit logs are retained in the backup bucket.
+const defaultAuditRetentionDays = 90
+
+// Enables the audit devices in Vault.
+// ctx: Pulumi context.
+// provider: Vault provider.
+// auditConfig: The audit configuration.
+func enableAuditDevices(
+	ctx *pulumi.Context,
+	provider *vault.Provider,
+	auditConfig *vaultConf.AuditConfig,
+) error {
+	_, fErr := vault.NewAudit(ctx, "vault-audit-file", &vault.AuditArgs{
+		Type:        pulumi.String("file"),
+		Path:        pulumi.String("file"),
+		Description: pulumi.String("File audit device"),
+		Options: pulumi.StringMap{
+			"file_path": pulumi.String(auditLogPath),
+			"format":    pulumi.String("json"),
+		},
+	}, pulumi.Provider(provider))
+	if fErr != nil {
+		return fErr
+	}
+
+	if auditConfig == nil {
+		return nil
+	}
+
+	if auditConfig.Syslog != nil {
+		_, sErr := vault.NewAudit(ctx, "vault-audit-syslog", &vault.AuditArgs{
+			Type:        pulumi.String("syslog"),
+			Path:        pulumi.String("syslog"),
+			Description: pulumi.String("Syslog audit device"),
+			Options: pulumi.StringMap{
+				"facility": pulumi.String(defaults.GetOrDefault(auditConfig.Syslog.Facility, "AUTH")),
+				"tag":      pulumi.String(defaults.GetOrDefault(auditConfig.Syslog.Tag, "vault")),
+				"format":   pulumi.String("json"),
+			},
+		}, pulumi.Provider(provider))
+		if sErr != nil {
+			return sErr
+		}
+	}
+
+	if auditConfig.Socket != nil {
+		if auditConfig.Socket.Address == nil || *auditConfig.Socket.Address == "" {
+			return fmt.Errorf("%w: socket: missing address", ErrInvalidAuditConfig)
+		}
+		_, sErr := vault.NewAudit(ctx, "vault-audit-socket", &vault.AuditArgs{
+			Type:        pulumi.String("socket"),
+			Path:        pulumi.String("socket"),
+			Description: pulumi.String("Socket audit device"),
+			Options: pulumi.StringMap{
+				"address":     pulumi.String(*auditConfig.Socket.Address),
+				"socket_type": pulumi.String(defaults.GetOrDefault(auditConfig.Socket.SocketType, "tcp")),
+				"format":      pulumi.String("json"),
+			},
+		}, pulumi.Provider(provider))
+		if sErr != nil {
+			return sErr
+		}
+	}
+
+	return nil
+}
+
+// auditRetentionDays returns the number of days audit logs are retained in the backup bucket.
+// auditConfig: The audit configuration.
+func auditRetentionDays(auditConfig *vaultConf.AuditConfig) int {
+	if auditConfig == nil {
+		return defaultAuditRetentionDays
+	}
+	return defaults.GetOrDefault(auditConfig.RetentionDays, defaultAuditRetentionDays)
+}
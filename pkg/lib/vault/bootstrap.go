@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// Bootstraps the credentials of the Pulumi provider (AppRole) and revokes the root token.
+// The AppRole is scoped to the paths managed by Pulumi and its secret ID expires after the configured TTL.
+// The bootstrap only runs once; use `pulumi up --replace` on the vault-bootstrap resource
+// together with a root token generated via the generate-root flow to run it again.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// keys: The Vault keys output of the initialization.
+// bootstrapConfig: The bootstrap configuration.
+// dependsOn: Pulumi resource option to specify dependencies.
+func bootstrap(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	keys *pulumi.AnyOutput,
+	bootstrapConfig *vaultConf.BootstrapConfig,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) (*pulumi.AnyOutput, error) {
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	revokeRootToken := true
+	var breakGlassToken *string
+	var secretIDTTL *string
+	if bootstrapConfig != nil {
+		revokeRootToken = bootstrapConfig.KeepRootToken == nil || !*bootstrapConfig.KeepRootToken
+		breakGlassToken = bootstrapConfig.RootToken
+		secretIDTTL = bootstrapConfig.SecretIDTTL
+	}
+
+	policy, pErr := file.ReadContents("./assets/vault/pulumi-policy.hcl")
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	script, sErr := template.Render("./assets/vault/bootstrap.sh.j2", map[string]any{
+		"revokeRootToken": revokeRootToken,
+		"policy":          policy,
+		"secretIdTtl":     defaults.GetOrDefault(secretIDTTL, "0"),
+	})
+	if sErr != nil {
+		return nil, sErr
+	}
+
+	rootToken, _ := keys.ApplyT(func(k any) string {
+		if breakGlassToken != nil {
+			return *breakGlassToken
+		}
+		return k.(*vault.Keys).RootToken
+	}).(pulumi.StringOutput)
+
+	cmd, cErr := remote.NewCommand(ctx, "vault-bootstrap", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(script),
+		Stdin:      pulumi.ToSecret(rootToken).(pulumi.StringOutput),
+		Connection: conn,
+	}, dependsOn,
+		pulumi.IgnoreChanges([]string{"create", "stdin"}),
+		pulumi.AdditionalSecretOutputs([]string{"stdout"}),
+	)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	data, _ := cmd.Stdout.ApplyT(func(stdout string) *vault.Bootstrap {
+		output, _ := block(stdout, "BOOTSTRAP")
+		parsed := parse(output)
+
+		roleID, _ := parsed["role_id"].(string)
+		secretID, _ := parsed["secret_id"].(string)
+		rootTokenRevoked, _ := parsed["root_token_revoked"].(bool)
+
+		return &vault.Bootstrap{
+			RoleID:           roleID,
+			SecretID:         secretID,
+			RootTokenRevoked: rootTokenRevoked,
+		}
+	}).(pulumi.AnyOutput)
+
+	return &data, nil
+}
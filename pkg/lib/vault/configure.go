@@ -3,15 +3,19 @@ package vault
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net"
+	"slices"
 
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/vault/secret"
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/vault/store"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
 	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault"
 	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault/kv"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
 	vaultModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
 )
 
@@ -19,36 +23,83 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// vaultInstall: The Vault installation command.
 // bucket: The GCS bucket to be used by Vault for storage.
 // dnsConfig: DNS configuration.
+// vaultConfig: Vault configuration.
+// secrets: Additional secrets of other services to store in Vault, by key.
 // dependsOn: Pulumi resource option to specify dependencies.
 func configure(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	vaultInstall *remote.Command,
 	bucket pulumi.StringOutput,
 	dnsConfig *dns.Config,
+	vaultConfig *vaultConf.Config,
+	secrets map[string]pulumi.StringMapOutput,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*pulumi.AnyOutput, error) {
 	address := fmt.Sprintf("https://%s", net.JoinHostPort(*dnsConfig.Entries["vault"].Domain, "8200"))
 
-	keys, iErr := initialize(ctx, sshIPv4, privateKeyPem, dependsOn)
+	keys, iErr := initialize(ctx, sshIPv4, privateKeyPem, proxy, vaultConfig, dependsOn)
 	if iErr != nil {
 		return nil, iErr
 	}
 
-	rootToken, _ := keys.ApplyT(func(k any) string {
-		return k.(*vaultModel.Keys).RootToken
-	}).(pulumi.StringOutput)
+	migration, mErr := migrateSeal(ctx, sshIPv4, privateKeyPem, proxy, keys, vaultConfig.Seal, dependsOn)
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	unsealed, uErr := unseal(
+		ctx,
+		sshIPv4,
+		privateKeyPem,
+		proxy,
+		vaultInstall,
+		keys,
+		vaultConfig,
+		pulumi.DependsOn(migration),
+	)
+	if uErr != nil {
+		return nil, uErr
+	}
+
+	bootstrapData, bErr := bootstrap(
+		ctx,
+		sshIPv4,
+		privateKeyPem,
+		proxy,
+		keys,
+		vaultConfig.Bootstrap,
+		pulumi.DependsOn(append(migration, unsealed...)),
+	)
+	if bErr != nil {
+		return nil, bErr
+	}
+
+	credentials, _ := bootstrapData.ApplyT(func(b any) map[string]string {
+		data, _ := b.(*vaultModel.Bootstrap)
+		return map[string]string{
+			"role_id":   data.RoleID,
+			"secret_id": data.SecretID,
+		}
+	}).(pulumi.StringMapOutput)
 	provider, pErr := vault.NewProvider(ctx, "vault", &vault.ProviderArgs{
 		Address: pulumi.StringPtr(address),
-		Token:   rootToken,
+		AuthLogin: &vault.ProviderAuthLoginArgs{
+			Path:       pulumi.String("auth/pulumi/login"),
+			Parameters: pulumi.ToSecret(credentials).(pulumi.StringMapOutput),
+		},
 	})
 	if pErr != nil {
 		return nil, pErr
 	}
 
-	polErr := createDefaultPolicies(ctx, provider)
+	polErr := createPolicies(ctx, provider, vaultConfig)
 	if polErr != nil {
 		return nil, polErr
 	}
@@ -63,18 +114,43 @@ func configure(
 		return nil, ghErr
 	}
 
-	data, _ := pulumi.All(bucket, address, keys).ApplyT(func(vs []any) *vaultModel.Instance {
+	auErr := enableAuditDevices(ctx, provider, vaultConfig.Audit)
+	if auErr != nil {
+		return nil, auErr
+	}
+
+	secretsEngines, seErr := createSecretsEngines(ctx, provider, address, vaultConfig.SecretsEngines)
+	if seErr != nil {
+		return nil, seErr
+	}
+	if secretsEngines.SSH != nil {
+		if _, tErr := trustSSHCA(ctx, sshIPv4, privateKeyPem, proxy, secretsEngines.SSHCAPublicKey); tErr != nil {
+			return nil, tErr
+		}
+	}
+
+	data, _ := pulumi.All(bucket, address, keys, bootstrapData).ApplyT(func(vs []any) *vaultModel.Instance {
 		vBucket, _ := vs[0].(string)
 		vAddress, _ := vs[1].(string)
 		vKeys, _ := vs[2].(*vaultModel.Keys)
+		vBootstrap, _ := vs[3].(*vaultModel.Bootstrap)
+
+		// a revoked root token must not be stored or exported anymore
+		if vBootstrap.RootTokenRevoked {
+			vKeys = &vaultModel.Keys{
+				RecoveryKeys: vKeys.RecoveryKeys,
+			}
+		}
 
 		ownedSecrets, _ := storeVaultSecrets(ctx, vKeys, provider)
+		storeServiceSecrets(ctx, ownedSecrets.Mount, secrets, provider)
 
 		return &vaultModel.Instance{
-			Bucket:       vBucket,
-			Address:      vAddress,
-			Keys:         vKeys,
-			OwnedSecrets: ownedSecrets,
+			Bucket:         vBucket,
+			Address:        vAddress,
+			Keys:           vKeys,
+			OwnedSecrets:   ownedSecrets,
+			SecretsEngines: secretsEngines,
 		}
 	}).(pulumi.AnyOutput)
 
@@ -101,20 +177,24 @@ func storeVaultSecrets(
 		return nil, err
 	}
 
-	value, _ := json.Marshal(map[string]string{
-		"rootToken":    keys.RootToken,
-		"recoveryKey1": keys.RecoveryKeys[0],
-		"recoveryKey2": keys.RecoveryKeys[1],
-		"recoveryKey3": keys.RecoveryKeys[2],
-		"recoveryKey4": keys.RecoveryKeys[3],
-		"recoveryKey5": keys.RecoveryKeys[4],
-	})
+	values := map[string]string{
+		"rootToken": keys.RootToken,
+	}
+	for i, key := range keys.RecoveryKeys {
+		values[fmt.Sprintf("recoveryKey%d", i+1)] = key
+	}
+	value, _ := json.Marshal(values)
+	opts := []pulumi.ResourceOption{pulumi.Provider(provider)}
+	// the keys are not known anymore once the initialization output was removed: keep the stored ones
+	if keys.RootToken == "" && len(keys.RecoveryKeys) == 0 {
+		opts = append(opts, pulumi.IgnoreChanges([]string{"dataJson"}))
+	}
 	secret, _ := mount.Path.ApplyT(func(path string) *kv.SecretV2 {
 		kv, _ := secret.Create(ctx, &secret.CreateOptions{
 			Path:          path,
 			Key:           "keys",
 			Value:         pulumi.String(value),
-			PulumiOptions: []pulumi.ResourceOption{pulumi.Provider(provider)},
+			PulumiOptions: opts,
 		})
 		return kv
 	}).(kv.SecretV2Output)
@@ -124,3 +204,32 @@ func storeVaultSecrets(
 		Keys:  &secret,
 	}, nil
 }
+
+// Stores the secrets of other services in Vault's KV secrets engine.
+// ctx: Pulumi context
+// mount: The KV mount to store the secrets in
+// secrets: The secrets to store, by key
+// provider: Vault provider
+func storeServiceSecrets(
+	ctx *pulumi.Context,
+	mount *vault.Mount,
+	secrets map[string]pulumi.StringMapOutput,
+	provider *vault.Provider,
+) {
+	for _, key := range slices.Sorted(maps.Keys(secrets)) {
+		value, _ := secrets[key].ApplyT(func(values map[string]string) string {
+			v, _ := json.Marshal(values)
+			return string(v)
+		}).(pulumi.StringOutput)
+
+		mount.Path.ApplyT(func(path string) error {
+			_, err := secret.Create(ctx, &secret.CreateOptions{
+				Path:          path,
+				Key:           key,
+				Value:         value,
+				PulumiOptions: []pulumi.ResourceOption{pulumi.Provider(provider)},
+			})
+			return err
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package vault
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/sanitize"
+	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault"
+	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault/pkisecret"
+	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault/ssh"
+	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault/transit"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
+	vaultModel "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
+)
+
+const (
+	// defaultPKIKeyBits is the default number of bits of the PKI CA keys.
+	defaultPKIKeyBits = 384
+	// pkiRootMaxLeaseTTLSeconds is the maximum lease TTL of the root PKI mount (10 years).
+	pkiRootMaxLeaseTTLSeconds = 315360000
+	// pkiIntermediateMaxLeaseTTLSeconds is the maximum lease TTL of the intermediate PKI mount (5 years).
+	pkiIntermediateMaxLeaseTTLSeconds = 157680000
+)
+
+// Creates the configured secrets engines in Vault.
+// ctx: Pulumi context.
+// provider: Vault provider.
+// address: The Vault server address.
+// enginesConfig: The secrets engines configuration.
+func createSecretsEngines(
+	ctx *pulumi.Context,
+	provider *vault.Provider,
+	address string,
+	enginesConfig *vaultConf.SecretsEnginesConfig,
+) (*vaultModel.SecretsEngines, error) {
+	engines := &vaultModel.SecretsEngines{}
+	if enginesConfig == nil {
+		return engines, nil
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(enginesConfig.KV)) {
+		mount, err := createKVEngine(ctx, provider, path, enginesConfig.KV[path])
+		if err != nil {
+			return nil, err
+		}
+		engines.KV = append(engines.KV, mount)
+	}
+
+	if enginesConfig.PKI != nil {
+		root, intermediate, err := createPKIEngine(ctx, provider, address, enginesConfig.PKI)
+		if err != nil {
+			return nil, err
+		}
+		engines.PKIRoot = root
+		engines.PKIIntermediate = intermediate
+	}
+
+	if enginesConfig.Transit != nil {
+		mount, err := createTransitEngine(ctx, provider, enginesConfig.Transit)
+		if err != nil {
+			return nil, err
+		}
+		engines.Transit = mount
+	}
+
+	if enginesConfig.SSH != nil {
+		mount, ca, err := createSSHEngine(ctx, provider, enginesConfig.SSH)
+		if err != nil {
+			return nil, err
+		}
+		engines.SSH = mount
+		engines.SSHCAPublicKey = ca.PublicKey
+	}
+
+	return engines, nil
+}
+
+// Creates a KV v2 secrets engine.
+// ctx: Pulumi context.
+// provider: Vault provider.
+// path: The mount path.
+// kvConfig: The KV configuration.
+func createKVEngine(
+	ctx *pulumi.Context,
+	provider *vault.Provider,
+	path string,
+	kvConfig *vaultConf.KVConfig,
+) (*vault.Mount, error) {
+	description := fmt.Sprintf("KV secrets engine: %s", path)
+	if kvConfig != nil && kvConfig.Description != nil {
+		description = *kvConfig.Description
+	}
+
+	return vault.NewMount(ctx, fmt.Sprintf("vault-mount-kv-%s", sanitize.Text(path)), &vault.MountArgs{
+		Path:        pulumi.String(path),
+		Type:        pulumi.String("kv"),
+		Description: pulumi.String(description),
+		Options: pulumi.StringMap{
+			"version": pulumi.String("2"),
+		},
+	}, pulumi.Provider(provider))
+}
+
+// Creates a two-tier PKI with a root CA signing an intermediate CA, and the roles issued by the intermediate CA.
+// ctx: Pulumi context.
+// provider: Vault provider.
+// address: The Vault server address.
+// pkiConfig: The PKI configuration.
+//
+//nolint:funlen // the PKI chain is created in a single place for readability
+func createPKIEngine(
+	ctx *pulumi.Context,
+	provider *vault.Provider,
+	address string,
+	pkiConfig *vaultConf.PKIConfig,
+) (*vault.Mount, *vault.Mount, error) {
+	if pkiConfig.CommonName == nil || *pkiConfig.CommonName == "" {
+		return nil, nil, fmt.Errorf("%w: pki: missing common name", ErrInvalidSecretsEngineConfig)
+	}
+
+	opts := []pulumi.ResourceOption{pulumi.Provider(provider)}
+
+	keyType := defaults.GetOrDefault(pkiConfig.KeyType, "ec")
+	keyBits := defaults.GetOrDefault(pkiConfig.KeyBits, defaultPKIKeyBits)
+
+	rootMount, rmErr := vault.NewMount(ctx, "vault-mount-pki-root", &vault.MountArgs{
+		Path:               pulumi.String("pki"),
+		Type:               pulumi.String("pki"),
+		Description:        pulumi.String("PKI root certificate authority"),
+		MaxLeaseTtlSeconds: pulumi.Int(pkiRootMaxLeaseTTLSeconds),
+	}, opts...)
+	if rmErr != nil {
+		return nil, nil, rmErr
+	}
+	rootCert, rcErr := pkisecret.NewSecretBackendRootCert(ctx, "vault-pki-root-cert", &pkisecret.SecretBackendRootCertArgs{
+		Backend:      rootMount.Path,
+		Type:         pulumi.String("internal"),
+		CommonName:   pulumi.Sprintf("%s Root CA", *pkiConfig.CommonName),
+		IssuerName:   pulumi.String("root"),
+		Organization: pulumi.StringPtrFromPtr(pkiConfig.Organization),
+		KeyType:      pulumi.String(keyType),
+		KeyBits:      pulumi.Int(keyBits),
+		Ttl:          pulumi.String(defaults.GetOrDefault(pkiConfig.RootTTL, "87600h")),
+	}, opts...)
+	if rcErr != nil {
+		return nil, nil, rcErr
+	}
+
+	intermediateMount, imErr := vault.NewMount(ctx, "vault-mount-pki-intermediate", &vault.MountArgs{
+		Path:               pulumi.String("pki-intermediate"),
+		Type:               pulumi.String("pki"),
+		Description:        pulumi.String("PKI intermediate certificate authority"),
+		MaxLeaseTtlSeconds: pulumi.Int(pkiIntermediateMaxLeaseTTLSeconds),
+	}, opts...)
+	if imErr != nil {
+		return nil, nil, imErr
+	}
+	csr, csrErr := pkisecret.NewSecretBackendIntermediateCertRequest(
+		ctx,
+		"vault-pki-intermediate-csr",
+		&pkisecret.SecretBackendIntermediateCertRequestArgs{
+			Backend:      intermediateMount.Path,
+			Type:         pulumi.String("internal"),
+			CommonName:   pulumi.Sprintf("%s Intermediate CA", *pkiConfig.CommonName),
+			Organization: pulumi.StringPtrFromPtr(pkiConfig.Organization),
+			KeyType:      pulumi.String(keyType),
+			KeyBits:      pulumi.Int(keyBits),
+		},
+		opts...)
+	if csrErr != nil {
+		return nil, nil, csrErr
+	}
+	signed, sErr := pkisecret.NewSecretBackendRootSignIntermediate(
+		ctx,
+		"vault-pki-intermediate-signed",
+		&pkisecret.SecretBackendRootSignIntermediateArgs{
+			Backend:      rootMount.Path,
+			Csr:          csr.Csr,
+			CommonName:   pulumi.Sprintf("%s Intermediate CA", *pkiConfig.CommonName),
+			Organization: pulumi.StringPtrFromPtr(pkiConfig.Organization),
+			Ttl:          pulumi.String(defaults.GetOrDefault(pkiConfig.IntermediateTTL, "43800h")),
+		},
+		append(opts, pulumi.DependsOn([]pulumi.Resource{rootCert}))...)
+	if sErr != nil {
+		return nil, nil, sErr
+	}
+	setSigned, ssErr := pkisecret.NewSecretBackendIntermediateSetSigned(
+		ctx,
+		"vault-pki-intermediate-set-signed",
+		&pkisecret.SecretBackendIntermediateSetSignedArgs{
+			Backend:     intermediateMount.Path,
+			Certificate: pulumi.Sprintf("%s\n%s", signed.Certificate, rootCert.Certificate),
+		},
+		opts...)
+	if ssErr != nil {
+		return nil, nil, ssErr
+	}
+
+	mounts := map[string]*vault.Mount{"root": rootMount, "intermediate": intermediateMount}
+	for _, name := range slices.Sorted(maps.Keys(mounts)) {
+		mount := mounts[name]
+		_, uErr := pkisecret.NewSecretBackendConfigUrls(
+			ctx,
+			fmt.Sprintf("vault-pki-%s-urls", name),
+			&pkisecret.SecretBackendConfigUrlsArgs{
+				Backend:               mount.Path,
+				IssuingCertificates:   pulumi.StringArray{pulumi.Sprintf("%s/v1/%s/ca", address, mount.Path)},
+				CrlDistributionPoints: pulumi.StringArray{pulumi.Sprintf("%s/v1/%s/crl", address, mount.Path)},
+			},
+			opts...)
+		if uErr != nil {
+			return nil, nil, uErr
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pkiConfig.Roles)) {
+		role := pkiConfig.Roles[name]
+		_, rErr := pkisecret.NewSecretBackendRole(
+			ctx,
+			fmt.Sprintf("vault-pki-role-%s", sanitize.Text(name)),
+			&pkisecret.SecretBackendRoleArgs{
+				Backend:          intermediateMount.Path,
+				Name:             pulumi.String(name),
+				AllowedDomains:   pulumi.ToStringArray(role.AllowedDomains),
+				AllowBareDomains: pulumi.Bool(role.AllowBareDomains),
+				AllowSubdomains:  pulumi.Bool(role.AllowSubdomains),
+				ServerFlag:       pulumi.Bool(defaults.GetOrDefault(role.ServerFlag, true)),
+				ClientFlag:       pulumi.Bool(defaults.GetOrDefault(role.ClientFlag, true)),
+				KeyType:          pulumi.String(keyType),
+				KeyBits:          pulumi.Int(keyBits),
+				Ttl:              pulumi.StringPtrFromPtr(role.TTL),
+				MaxTtl:           pulumi.StringPtrFromPtr(role.MaxTTL),
+			},
+			append(opts, pulumi.DependsOn([]pulumi.Resource{setSigned}))...)
+		if rErr != nil {
+			return nil, nil, rErr
+		}
+	}
+
+	return rootMount, intermediateMount, nil
+}
+
+// Creates the transit secrets engine and its named keys.
+// ctx: Pulumi context.
+// provider: Vault provider.
+// transitConfig: The transit configuration.
+func createTransitEngine(
+	ctx *pulumi.Context,
+	provider *vault.Provider,
+	transitConfig *vaultConf.TransitConfig,
+) (*vault.Mount, error) {
+	mount, mErr := vault.NewMount(ctx, "vault-mount-transit", &vault.MountArgs{
+		Path:        pulumi.String("transit"),
+		Type:        pulumi.String("transit"),
+		Description: pulumi.String("Transit encryption"),
+	}, pulumi.Provider(provider))
+	if mErr != nil {
+		return nil, mErr
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(transitConfig.Keys)) {
+		key := transitConfig.Keys[name]
+		if key == nil {
+			key = &vaultConf.TransitKeyConfig{}
+		}
+		_, kErr := transit.NewSecretBackendKey(
+			ctx,
+			fmt.Sprintf("vault-transit-key-%s", sanitize.Text(name)),
+			&transit.SecretBackendKeyArgs{
+				Backend:          mount.Path,
+				Name:             pulumi.String(name),
+				Type:             pulumi.String(defaults.GetOrDefault(key.Type, "aes256-gcm96")),
+				Exportable:       pulumi.Bool(key.Exportable),
+				DeletionAllowed:  pulumi.Bool(key.DeletionAllowed),
+				AutoRotatePeriod: pulumi.Int(defaults.GetOrDefault(key.AutoRotatePeriod, 0)),
+			},
+			pulumi.Provider(provider))
+		if kErr != nil {
+			return nil, kErr
+		}
+	}
+
+	return mount, nil
+}
+
+// Creates the SSH certificate authority secrets engine and its roles signing user certificates.
+// ctx: Pulumi context.
+// provider: Vault provider.
+// sshConfig: The SSH configuration.
+func createSSHEngine(
+	ctx *pulumi.Context,
+	provider *vault.Provider,
+	sshConfig *vaultConf.SSHConfig,
+) (*vault.Mount, *ssh.SecretBackendCa, error) {
+	mount, mErr := vault.NewMount(ctx, "vault-mount-ssh", &vault.MountArgs{
+		Path:        pulumi.String("ssh"),
+		Type:        pulumi.String("ssh"),
+		Description: pulumi.String("SSH certificate authority"),
+	}, pulumi.Provider(provider))
+	if mErr != nil {
+		return nil, nil, mErr
+	}
+
+	ca, caErr := ssh.NewSecretBackendCa(ctx, "vault-ssh-ca", &ssh.SecretBackendCaArgs{
+		Backend:            mount.Path,
+		GenerateSigningKey: pulumi.Bool(true),
+		KeyType:            pulumi.String(defaults.GetOrDefault(sshConfig.KeyType, "ed25519")),
+	}, pulumi.Provider(provider))
+	if caErr != nil {
+		return nil, nil, caErr
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(sshConfig.Roles)) {
+		role := sshConfig.Roles[name]
+		_, rErr := ssh.NewSecretBackendRole(
+			ctx,
+			fmt.Sprintf("vault-ssh-role-%s", sanitize.Text(name)),
+			&ssh.SecretBackendRoleArgs{
+				Backend:               mount.Path,
+				Name:                  pulumi.String(name),
+				KeyType:               pulumi.String("ca"),
+				AllowUserCertificates: pulumi.Bool(true),
+				AllowedUsers:          pulumi.String(strings.Join(role.AllowedUsers, ",")),
+				DefaultUser:           pulumi.StringPtrFromPtr(role.DefaultUser),
+				AllowedExtensions:     pulumi.String("permit-pty,permit-port-forwarding"),
+				DefaultExtensions: pulumi.StringMap{
+					"permit-pty": pulumi.String(""),
+				},
+				Ttl:    pulumi.String(defaults.GetOrDefault(role.TTL, "30m")),
+				MaxTtl: pulumi.String(defaults.GetOrDefault(role.MaxTTL, "1h")),
+			},
+			pulumi.Provider(provider),
+			pulumi.DependsOn([]pulumi.Resource{ca}))
+		if rErr != nil {
+			return nil, nil, rErr
+		}
+	}
+
+	return mount, ca, nil
+}
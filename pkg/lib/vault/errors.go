@@ -0,0 +1,24 @@
+package vault
+
+import "errors"
+
+var (
+	// ErrInitOutputNotFound is returned if the initialization output is missing from the command output.
+	ErrInitOutputNotFound = errors.New("vault: initialization output not found")
+	// ErrInitOutputInvalid is returned if the initialization output cannot be parsed.
+	ErrInitOutputInvalid = errors.New("vault: initialization output is invalid")
+	// ErrRootTokenMissing is returned if the initialization output does not contain a root token.
+	ErrRootTokenMissing = errors.New("vault: root token missing in initialization output")
+	// ErrRecoveryKeysMismatch is returned if the number of recovery keys does not match the configured shares.
+	ErrRecoveryKeysMismatch = errors.New("vault: number of recovery keys does not match the recovery shares")
+	// ErrInvalidRecoveryConfig is returned if the recovery shares, threshold, or PGP keys are inconsistent.
+	ErrInvalidRecoveryConfig = errors.New("vault: invalid recovery key configuration")
+	// ErrInvalidSealConfig is returned if the seal configuration is invalid.
+	ErrInvalidSealConfig = errors.New("vault: invalid seal configuration")
+	// ErrInvalidSecretsEngineConfig is returned if a secrets engine configuration is invalid.
+	ErrInvalidSecretsEngineConfig = errors.New("vault: invalid secrets engine configuration")
+	// ErrInvalidAuditConfig is returned if an audit device configuration is invalid.
+	ErrInvalidAuditConfig = errors.New("vault: invalid audit configuration")
+	// ErrInvalidPolicy is returned if a policy is not valid HCL.
+	ErrInvalidPolicy = errors.New("vault: invalid policy")
+)
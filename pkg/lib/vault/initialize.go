@@ -1,74 +1,163 @@
 package vault
 
 import (
+	"fmt"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 
-	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
 	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
 )
 
+// defaultRecoveryShares is the default number of recovery key shares created during initialization.
+const defaultRecoveryShares = 5
+
+// defaultRecoveryThreshold is the default number of recovery key shares required to reconstruct the root key.
+const defaultRecoveryThreshold = 3
+
+// initOutput is the output of `vault operator init -format=json`.
+// UnsealKeysB64 is only set for the Shamir seal; RecoveryKeys is only set by the legacy (text-based) output.
+type initOutput struct {
+	RootToken       string   `yaml:"root_token"`
+	RecoveryKeysB64 []string `yaml:"recovery_keys_b64"`
+	UnsealKeysB64   []string `yaml:"unseal_keys_b64"`
+	RecoveryKeys    []string `yaml:"recovery_keys"`
+}
+
 // Initializes Vault on the remote server via SSH.
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
-// bucket: The GCS bucket to be used by Vault for storage.
-// dnsConfig: DNS configuration.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// vaultConfig: Vault configuration.
 // dependsOn: Pulumi resource option to specify dependencies.
 func initialize(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	vaultConfig *vaultConf.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*pulumi.AnyOutput, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	recoveryShares := defaultRecoveryShares
+	recoveryThreshold := defaultRecoveryThreshold
+	if vaultConfig.Init != nil {
+		recoveryShares = defaults.GetOrDefault(vaultConfig.Init.RecoveryShares, defaultRecoveryShares)
+		recoveryThreshold = defaults.GetOrDefault(vaultConfig.Init.RecoveryThreshold, defaultRecoveryThreshold)
+	}
+	var recoveryPGPKeys []string
+	if vaultConfig.Bootstrap != nil {
+		recoveryPGPKeys = vaultConfig.Bootstrap.RecoveryPGPKeys
+	}
+	if vErr := validateRecoveryConfig(recoveryShares, recoveryThreshold, recoveryPGPKeys); vErr != nil {
+		return nil, vErr
 	}
 
-	script, sErr := file.ReadContents("./assets/vault/init.sh")
+	script, sErr := template.Render("./assets/vault/init.sh.j2", map[string]any{
+		"recoveryShares":    recoveryShares,
+		"recoveryThreshold": recoveryThreshold,
+		"recoveryPgpKeys":   recoveryPGPKeys,
+		"shamir":            sealType(vaultConfig.Seal) == sealTypeShamir,
+	})
 	if sErr != nil {
 		return nil, sErr
 	}
 
+	// the initialization must never run again once Vault is initialized: the root token is revoked
+	// and the initialization output removed after bootstrapping
 	cmd, cErr := remote.NewCommand(ctx, "vault-init", &remote.CommandArgs{
 		Create:     pulumi.StringPtr(script),
 		Connection: conn,
 	}, dependsOn, pulumi.Timeouts(&pulumi.CustomTimeouts{
 		Create: "40m",
 		Update: "40m",
-	}))
+	}), pulumi.IgnoreChanges([]string{"create"}), pulumi.AdditionalSecretOutputs([]string{"stdout"}))
 	if cErr != nil {
 		return nil, cErr
 	}
 
-	keys, _ := cmd.Stdout.ApplyT(func(stdout string) *vault.Keys {
-		startBlock := strings.LastIndex(stdout, "--START TOKENS--")
-		endBlock := strings.LastIndex(stdout, "--END TOKENS--")
-		tokens := stdout[startBlock+len("--START TOKENS--") : endBlock]
-		parsedTokens := parse(tokens)
-
-		var recoveryKeys []string
-		if rk, ok := parsedTokens["recovery_keys"].([]any); ok {
-			for _, key := range rk {
-				recoveryKeys = append(recoveryKeys, key.(string))
-			}
-		}
-
-		return &vault.Keys{
-			RootToken:    parsedTokens["root_token"].(string),
-			RecoveryKeys: recoveryKeys,
-		}
+	keys, _ := cmd.Stdout.ApplyT(func(stdout string) (*vault.Keys, error) {
+		return parseKeys(stdout, recoveryShares)
 	}).(pulumi.AnyOutput)
 
 	return &keys, nil
 }
 
+// validateRecoveryConfig validates the recovery key configuration.
+// shares: The number of recovery key shares.
+// threshold: The number of recovery key shares required to reconstruct the root key.
+// pgpKeys: The PGP public keys used to encrypt the recovery keys.
+func validateRecoveryConfig(shares int, threshold int, pgpKeys []string) error {
+	if shares < 1 || threshold < 1 || threshold > shares {
+		return fmt.Errorf("%w: threshold %d must be between 1 and the shares %d", ErrInvalidRecoveryConfig, threshold, shares)
+	}
+	if len(pgpKeys) > 0 && len(pgpKeys) != shares {
+		return fmt.Errorf("%w: expected %d recovery PGP keys, got %d", ErrInvalidRecoveryConfig, shares, len(pgpKeys))
+	}
+	return nil
+}
+
+// parseKeys parses the Vault keys from the output of the initialization script.
+// The legacy (text-based) output format is supported for already initialized instances.
+// An initialized Vault whose initialization output was removed after bootstrapping yields empty keys.
+// stdout: The output of the initialization script.
+// recoveryShares: The expected number of recovery keys.
+func parseKeys(stdout string, recoveryShares int) (*vault.Keys, error) {
+	tokens, ok := block(stdout, "TOKENS")
+	if !ok {
+		if _, initialized := block(stdout, "INITIALIZED"); initialized {
+			return &vault.Keys{}, nil
+		}
+		return nil, ErrInitOutputNotFound
+	}
+
+	var out initOutput
+	if err := yaml.Unmarshal([]byte(tokens), &out); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInitOutputInvalid, err)
+	}
+
+	if out.RootToken == "" {
+		return nil, ErrRootTokenMissing
+	}
+
+	recoveryKeys := out.RecoveryKeysB64
+	if len(recoveryKeys) == 0 {
+		recoveryKeys = out.UnsealKeysB64
+	}
+	if len(recoveryKeys) == 0 {
+		recoveryKeys = out.RecoveryKeys
+	}
+	if len(recoveryKeys) != recoveryShares {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrRecoveryKeysMismatch, recoveryShares, len(recoveryKeys))
+	}
+
+	return &vault.Keys{
+		RootToken:    out.RootToken,
+		RecoveryKeys: recoveryKeys,
+	}, nil
+}
+
+// block returns the content between the last "--START <name>--" and "--END <name>--" markers in s.
+// The second return value reports whether the markers were found.
+func block(s string, name string) (string, bool) {
+	startMarker := fmt.Sprintf("--START %s--", name)
+	startBlock := strings.LastIndex(s, startMarker)
+	endBlock := strings.LastIndex(s, fmt.Sprintf("--END %s--", name))
+	if startBlock < 0 || endBlock < startBlock {
+		return "", false
+	}
+	return s[startBlock+len(startMarker) : endBlock], true
+}
+
 // parse parses a YAML-formatted string into a map[string]any.
 // On error it returns an empty map.
 func parse(s string) map[string]any {
@@ -1,6 +1,9 @@
 package vault
 
 import (
+	"fmt"
+	"maps"
+
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
 	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
@@ -9,6 +12,7 @@ import (
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/lib/config"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/google"
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
 	vaultData "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
 )
@@ -17,24 +21,24 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // vaultData: Vault configuration data.
 // dnsConfig: DNS configuration.
 // googleConfig: Google Cloud configuration.
+// vaultConfig: Vault configuration.
 // dependsOn: Pulumi resource option to specify dependencies.
 func installer(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	vaultData *vaultData.Data,
 	googleConfig *google.Config,
 	dnsConfig *dns.Config,
+	vaultConfig *vaultConf.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -43,8 +47,24 @@ func installer(
 		return nil, prepErr
 	}
 
+	if sErr := validateSealConfig(vaultConfig.Seal); sErr != nil {
+		return nil, sErr
+	}
+	if usesSealType(vaultConfig.Seal, sealTypeGCPCKMS) && googleConfig.EncryptionKey == nil {
+		return nil, fmt.Errorf("%w: the gcpckms seal requires gcp.encryptionKey", ErrInvalidSealConfig)
+	}
+
+	var pkcs11Image, license string
+	if usesSealType(vaultConfig.Seal, sealTypePKCS11) {
+		pkcs11Image = *vaultConfig.Seal.PKCS11.Image
+		license = *vaultConfig.Seal.PKCS11.License
+	}
+
 	dockerCompose, dcErr := template.Render("./assets/vault/docker-compose.yml.j2", map[string]any{
-		"domain": dnsConfig.Entries["vault"].Domain,
+		"domain":      dnsConfig.Entries["vault"].Domain,
+		"auditSyslog": vaultConfig.Audit != nil && vaultConfig.Audit.Syslog != nil,
+		"pkcs11Image": pkcs11Image,
+		"license":     license,
 	})
 	if dcErr != nil {
 		return nil, dcErr
@@ -55,7 +75,7 @@ func installer(
 			return *hash
 		})
 	dockerComposeCopy := dockerComposeHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			"remote-copy-vault-docker-compose",
 			&remote.CopyToRemoteArgs{
@@ -64,16 +84,18 @@ func installer(
 				Triggers:   pulumi.Array{dockerComposeHash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
-	vaultConfig, _ := pulumi.All(vaultData.ScalewayBucket.Name, vaultData.Application.Key.AccessKey, vaultData.Application.Key.SecretKey).ApplyT(func(args []any) string {
+	vaultServerConfig, _ := pulumi.All(vaultData.ScalewayBucket.Name, vaultData.Application.Key.AccessKey, vaultData.Application.Key.SecretKey).ApplyT(func(args []any) string {
 		scalewayBucket, _ := args[0].(string)
 		accessKey, _ := args[1].(string)
 		secretKey, _ := args[2].(string)
 
-		tpl, _ := template.Render("./assets/vault/config.hcl.j2", map[string]any{
+		data := sealTemplateData(vaultConfig.Seal)
+		maps.Copy(data, map[string]any{
 			"gcp": googleConfig,
 			"scaleway": map[string]string{
 				"bucket":    scalewayBucket,
@@ -82,23 +104,45 @@ func installer(
 				"secretKey": secretKey,
 			},
 		})
+		tpl, _ := template.Render("./assets/vault/config.hcl.j2", data)
 		return tpl
 	}).(pulumi.StringOutput)
-	vaultConfigHash := file.WritePulumi("./outputs/vault_vault-config.hcl", vaultConfig).
+	vaultConfigHash := file.WritePulumi("./outputs/vault_vault-config.hcl", vaultServerConfig).
 		ApplyT(func(_ string) string {
 			hash, _ := file.Hash("./outputs/vault_vault-config.hcl")
 			return *hash
 		})
 	vaultConfigCopy := vaultConfigHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(ctx, "remote-copy-vault-config", &remote.CopyToRemoteArgs{
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-vault-config", &remote.CopyToRemoteArgs{
 			Source:     pulumi.NewFileAsset("./outputs/vault_vault-config.hcl"),
 			RemotePath: pulumi.String("/opt/vault/config/vault-config.hcl"),
 			Triggers:   pulumi.Array{vaultConfigHash},
 			Connection: conn,
-		}, opts...)
+		}, 0o644, opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
+	generateRootHash, grErr := file.Hash("./assets/vault/generate-root.sh")
+	if grErr != nil {
+		return nil, grErr
+	}
+	generateRootCopy, grcErr := install.CopyToRemote(ctx, "remote-copy-vault-generate-root", &remote.CopyToRemoteArgs{
+		Source:     pulumi.NewFileAsset("./assets/vault/generate-root.sh"),
+		RemotePath: pulumi.String("/opt/vault/generate-root.sh"),
+		Triggers:   pulumi.Array{pulumi.String(*generateRootHash)},
+		Connection: conn,
+	}, 0o644, opts...)
+	if grcErr != nil {
+		return nil, grcErr
+	}
+
+	cronResources, cronErr := install.Cron(ctx, "vault", conn, map[string]any{
+		"retentionDays": auditRetentionDays(vaultConfig.Audit),
+	}, opts...)
+	if cronErr != nil {
+		return nil, cronErr
+	}
+
 	opts, systemdServiceHash, shErr := install.SystemDService(ctx, "vault", conn, opts...)
 	if shErr != nil {
 		return nil, shErr
@@ -113,5 +157,8 @@ func installer(
 		Update:     pulumi.StringPtr(installFn),
 		Triggers:   pulumi.Array{dockerComposeHash, pulumi.String(*systemdServiceHash), vaultConfigHash},
 		Connection: conn,
-	}, append(opts, install.CollectResourceOptions([]pulumi.Output{dockerComposeCopy, vaultConfigCopy})...)...)
+	}, append(
+		append(opts, pulumi.DependsOn([]pulumi.Resource{generateRootCopy})),
+		install.CollectResourceOptions(append(cronResources, dockerComposeCopy, vaultConfigCopy))...,
+	)...)
 }
@@ -3,10 +3,12 @@ package vault
 import (
 	"github.com/muhlba91/pulumi-shared-library/pkg/model/google/iam/serviceaccount"
 	"github.com/muhlba91/pulumi-shared-library/pkg/model/scaleway/iam/application"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/dns"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/google"
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
 	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
 )
 
@@ -14,18 +16,24 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // serviceAccount: The Google service account used for authentication.
 // application: The Scaleway application used for authentication.
 // dnsConfig: DNS configuration.
 // googleConfig: Google configuration containing project and other settings.
+// vaultConfig: Vault configuration.
+// secrets: Additional secrets of other services to store in Vault, by key.
 // dependsOn: List of Pulumi resources that this installation depends on.
 func Install(ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	serviceAccount *serviceaccount.User,
 	application *application.Application,
 	dnsConfig *dns.Config,
 	googleConfig *google.Config,
+	vaultConfig *vaultConf.Config,
+	secrets map[string]pulumi.StringMapOutput,
 	dependsOn []pulumi.Resource,
 ) (*vault.Data, *pulumi.AnyOutput, pulumi.Resource, error) {
 	vaultData, vdErr := createResources(ctx, serviceAccount, application)
@@ -37,9 +45,11 @@ func Install(ctx *pulumi.Context,
 		ctx,
 		sshIPv4,
 		privateKeyPem,
+		proxy,
 		vaultData,
 		googleConfig,
 		dnsConfig,
+		vaultConfig,
 		pulumi.DependsOn(dependsOn),
 	)
 	if vErr != nil {
@@ -50,8 +60,12 @@ func Install(ctx *pulumi.Context,
 		ctx,
 		sshIPv4,
 		privateKeyPem,
+		proxy,
+		vaultInstall,
 		vaultData.ScalewayBucket.Name,
 		dnsConfig,
+		vaultConfig,
+		secrets,
 		pulumi.DependsOn(append([]pulumi.Resource{vaultInstall}, dependsOn...)),
 	)
 	if viErr != nil {
@@ -1,63 +1,155 @@
 package vault
 
 import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/muhlba91/pulumi-shared-library/pkg/lib/vault/policy"
-	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
 	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
 )
 
-// Creates the default Vault policies.
+// policyTemplateSuffix is the file suffix of the policy templates.
+const policyTemplateSuffix = ".hcl.j2"
+
+// bootstrapPolicy is the name of the policy of the Pulumi provider, which is managed by the bootstrap.
+const bootstrapPolicy = "pulumi"
+
+// policyCapabilities are the capabilities supported by Vault policies.
+//
+//nolint:gochecknoglobals // global is acceptable here
+var policyCapabilities = []string{
+	"create", "read", "update", "patch", "delete", "list", "sudo", "deny", "subscribe", "recover",
+}
+
+// policyDocument is the schema of a Vault policy.
+type policyDocument struct {
+	Paths []policyPath `hcl:"path,block"`
+}
+
+// policyPath is the schema of a path rule of a Vault policy.
+type policyPath struct {
+	Path                string         `hcl:"path,label"`
+	Capabilities        []string       `hcl:"capabilities,optional"`
+	Policy              *string        `hcl:"policy,optional"`
+	AllowedParameters   hcl.Expression `hcl:"allowed_parameters,optional"`
+	DeniedParameters    hcl.Expression `hcl:"denied_parameters,optional"`
+	RequiredParameters  hcl.Expression `hcl:"required_parameters,optional"`
+	MinWrappingTTL      *string        `hcl:"min_wrapping_ttl,optional"`
+	MaxWrappingTTL      *string        `hcl:"max_wrapping_ttl,optional"`
+	ControlGroup        *policyBlock   `hcl:"control_group,block"`
+	SubscribeEventTypes []string       `hcl:"subscribe_event_types,optional"`
+}
+
+// policyBlock is a nested block of a path rule whose content is validated by Vault.
+type policyBlock struct {
+	Body hcl.Body `hcl:",remain"`
+}
+
+// Creates the Vault policies.
+// The default policies are discovered from the policy templates in ./assets/vault/policies;
+// additional policies are read from the configuration.
+// Policies removed from the templates or configuration are deleted from Vault.
 // ctx: Pulumi context.
 // provider: Vault provider.
-func createDefaultPolicies(
+// vaultConfig: Vault configuration.
+func createPolicies(
 	ctx *pulumi.Context,
 	provider *vault.Provider,
+	vaultConfig *vaultConf.Config,
 ) error {
-	policyDoc, rErr := file.ReadContents("./assets/vault/policies/admin.hcl")
+	policies, rErr := renderDefaultPolicies(vaultConfig)
 	if rErr != nil {
 		return rErr
 	}
-	_, pErr := policy.Create(ctx, &policy.CreateOptions{
-		Name:   "admin",
-		Policy: pulumi.String(policyDoc),
-		PulumiOptions: []pulumi.ResourceOption{
-			pulumi.Provider(provider),
-		},
-	})
-	if pErr != nil {
-		return pErr
+	maps.Copy(policies, vaultConfig.Policies)
+
+	for _, name := range slices.Sorted(maps.Keys(policies)) {
+		if vErr := validatePolicy(name, policies[name]); vErr != nil {
+			return vErr
+		}
+
+		_, pErr := policy.Create(ctx, &policy.CreateOptions{
+			Name:   name,
+			Policy: pulumi.String(policies[name]),
+			PulumiOptions: []pulumi.ResourceOption{
+				pulumi.Provider(provider),
+			},
+		})
+		if pErr != nil {
+			return pErr
+		}
 	}
 
-	policyDoc, rErr = file.ReadContents("./assets/vault/policies/manager.hcl")
-	if rErr != nil {
-		return rErr
+	return nil
+}
+
+// renderDefaultPolicies renders the policy templates in ./assets/vault/policies.
+// vaultConfig: Vault configuration.
+func renderDefaultPolicies(vaultConfig *vaultConf.Config) (map[string]string, error) {
+	var kvMounts []string
+	if vaultConfig.SecretsEngines != nil {
+		kvMounts = slices.Sorted(maps.Keys(vaultConfig.SecretsEngines.KV))
 	}
-	_, pErr = policy.Create(ctx, &policy.CreateOptions{
-		Name:   "manager",
-		Policy: pulumi.String(policyDoc),
-		PulumiOptions: []pulumi.ResourceOption{
-			pulumi.Provider(provider),
-		},
-	})
-	if pErr != nil {
-		return pErr
+
+	files, gErr := filepath.Glob("./assets/vault/policies/*" + policyTemplateSuffix)
+	if gErr != nil {
+		return nil, gErr
 	}
 
-	policyDoc, rErr = file.ReadContents("./assets/vault/policies/reader.hcl")
-	if rErr != nil {
-		return rErr
+	policies := make(map[string]string, len(files))
+	for _, f := range files {
+		doc, tErr := template.Render(f, map[string]any{
+			"kvMounts": kvMounts,
+		})
+		if tErr != nil {
+			return nil, tErr
+		}
+		policies[strings.TrimSuffix(filepath.Base(f), policyTemplateSuffix)] = doc
 	}
-	_, pErr = policy.Create(ctx, &policy.CreateOptions{
-		Name:   "reader",
-		Policy: pulumi.String(policyDoc),
-		PulumiOptions: []pulumi.ResourceOption{
-			pulumi.Provider(provider),
-		},
-	})
-	if pErr != nil {
-		return pErr
+	return policies, nil
+}
+
+// validatePolicy validates a policy against the schema of Vault policies: the document must only
+// contain path rules with known attributes and capabilities.
+// Vault parses policies with HCL v1; constructs only supported by HCL v1 are rejected.
+// name: The name of the policy.
+// doc: The policy document.
+func validatePolicy(name string, doc string) error {
+	if name == bootstrapPolicy {
+		return fmt.Errorf("%w: %s: the policy is managed by the bootstrap", ErrInvalidPolicy, name)
+	}
+
+	f, diags := hclparse.NewParser().ParseHCL([]byte(doc), name+".hcl")
+	if diags.HasErrors() {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidPolicy, name, diags.Error())
 	}
 
+	var policy policyDocument
+	if dDiags := gohcl.DecodeBody(f.Body, nil, &policy); dDiags.HasErrors() {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidPolicy, name, dDiags.Error())
+	}
+	if len(policy.Paths) == 0 {
+		return fmt.Errorf("%w: %s: no path rules", ErrInvalidPolicy, name)
+	}
+	for _, rule := range policy.Paths {
+		if len(rule.Capabilities) == 0 && rule.Policy == nil {
+			return fmt.Errorf("%w: %s: path %q: missing capabilities", ErrInvalidPolicy, name, rule.Path)
+		}
+		for _, capability := range rule.Capabilities {
+			if !slices.Contains(policyCapabilities, capability) {
+				return fmt.Errorf("%w: %s: path %q: unknown capability %q", ErrInvalidPolicy, name, rule.Path, capability)
+			}
+		}
+	}
 	return nil
 }
@@ -0,0 +1,229 @@
+package vault
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/defaults"
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	vaultConf "github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/vault"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/vault"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+const (
+	// sealTypeGCPCKMS is the GCP Cloud KMS auto-unseal.
+	sealTypeGCPCKMS = "gcpckms"
+	// sealTypeTransit is the Transit auto-unseal against another Vault.
+	sealTypeTransit = "transit"
+	// sealTypeShamir is the Shamir seal with operator-held unseal keys.
+	sealTypeShamir = "shamir"
+	// sealTypePKCS11 is the PKCS#11 (HSM) auto-unseal.
+	sealTypePKCS11 = "pkcs11"
+)
+
+// sealType returns the configured seal type.
+// sealConfig: The seal configuration.
+func sealType(sealConfig *vaultConf.SealConfig) string {
+	if sealConfig == nil {
+		return sealTypeGCPCKMS
+	}
+	return defaults.GetOrDefault(sealConfig.Type, sealTypeGCPCKMS)
+}
+
+// validateSealConfig validates the seal configuration.
+// sealConfig: The seal configuration.
+func validateSealConfig(sealConfig *vaultConf.SealConfig) error {
+	types := []string{sealType(sealConfig)}
+	if sealConfig != nil && sealConfig.MigrateFrom != nil {
+		types = append(types, *sealConfig.MigrateFrom)
+	}
+
+	for _, t := range types {
+		switch t {
+		case sealTypeGCPCKMS, sealTypeShamir:
+		case sealTypeTransit:
+			if sealConfig.Transit == nil {
+				return fmt.Errorf("%w: missing transit configuration", ErrInvalidSealConfig)
+			}
+		case sealTypePKCS11:
+			if sealConfig.PKCS11 == nil {
+				return fmt.Errorf("%w: missing pkcs11 configuration", ErrInvalidSealConfig)
+			}
+			if sealConfig.PKCS11.Image == nil || sealConfig.PKCS11.License == nil {
+				return fmt.Errorf("%w: pkcs11: the Vault Enterprise image and license are required", ErrInvalidSealConfig)
+			}
+			if sealConfig.PKCS11.Library == nil || sealConfig.PKCS11.Pin == nil ||
+				sealConfig.PKCS11.KeyLabel == nil || sealConfig.PKCS11.HMACKeyLabel == nil {
+				return fmt.Errorf("%w: pkcs11: the library, pin, keyLabel and hmacKeyLabel are required", ErrInvalidSealConfig)
+			}
+		default:
+			return fmt.Errorf("%w: unknown seal type %q", ErrInvalidSealConfig, t)
+		}
+	}
+
+	if len(types) > 1 && types[0] == types[1] {
+		return fmt.Errorf("%w: cannot migrate from %q to itself", ErrInvalidSealConfig, types[0])
+	}
+	return nil
+}
+
+// sealTemplateData returns the data to render the seal stanzas of the Vault configuration with.
+// The seal being migrated from is rendered disabled.
+// sealConfig: The seal configuration.
+func sealTemplateData(sealConfig *vaultConf.SealConfig) map[string]any {
+	seals := []map[string]any{
+		{"type": sealType(sealConfig), "disabled": false},
+	}
+	data := map[string]any{}
+
+	if sealConfig == nil {
+		data["seals"] = seals
+		return data
+	}
+
+	if sealConfig.MigrateFrom != nil {
+		seals = append(seals, map[string]any{"type": *sealConfig.MigrateFrom, "disabled": true})
+	}
+	data["seals"] = seals
+
+	if sealConfig.Transit != nil {
+		data["transit"] = map[string]string{
+			"address":       defaults.GetOrDefault(sealConfig.Transit.Address, ""),
+			"token":         defaults.GetOrDefault(sealConfig.Transit.Token, ""),
+			"mountPath":     defaults.GetOrDefault(sealConfig.Transit.MountPath, "transit/"),
+			"keyName":       defaults.GetOrDefault(sealConfig.Transit.KeyName, ""),
+			"tlsSkipVerify": strconv.FormatBool(defaults.GetOrDefault(sealConfig.Transit.TLSSkipVerify, false)),
+		}
+	}
+	data["pkcs11"] = sealConfig.PKCS11
+
+	return data
+}
+
+// usesSealType returns whether the seal type is in use, either as the current seal or the one being migrated from.
+// sealConfig: The seal configuration.
+// t: The seal type.
+func usesSealType(sealConfig *vaultConf.SealConfig, t string) bool {
+	types := []string{sealType(sealConfig)}
+	if sealConfig != nil && sealConfig.MigrateFrom != nil {
+		types = append(types, *sealConfig.MigrateFrom)
+	}
+	return slices.Contains(types, t)
+}
+
+// Migrates the Vault seal if a previous seal type is configured.
+// The installer renders the previous seal disabled; Vault starts in migration mode
+// and is unsealed with the migration keys (or the keys of the initialization).
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// keys: The Vault keys output of the initialization.
+// sealConfig: The seal configuration.
+// dependsOn: Pulumi resource option to specify dependencies.
+func migrateSeal(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	keys *pulumi.AnyOutput,
+	sealConfig *vaultConf.SealConfig,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) ([]pulumi.Resource, error) {
+	if sealConfig == nil || sealConfig.MigrateFrom == nil {
+		return nil, nil
+	}
+
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	script, sErr := file.ReadContents("./assets/vault/migrate-seal.sh")
+	if sErr != nil {
+		return nil, sErr
+	}
+
+	migrationKeys, _ := keys.ApplyT(func(k any) string {
+		if len(sealConfig.MigrationKeys) > 0 {
+			return strings.Join(sealConfig.MigrationKeys, "\n")
+		}
+		return strings.Join(k.(*vault.Keys).RecoveryKeys, "\n")
+	}).(pulumi.StringOutput)
+
+	cmd, cErr := remote.NewCommand(ctx, "vault-seal-migrate", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(script),
+		Stdin:      pulumi.ToSecret(migrationKeys).(pulumi.StringOutput),
+		Triggers:   pulumi.Array{pulumi.String(*sealConfig.MigrateFrom), pulumi.String(sealType(sealConfig))},
+		Connection: conn,
+	}, dependsOn, pulumi.Timeouts(&pulumi.CustomTimeouts{
+		Create: "20m",
+	}))
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	return []pulumi.Resource{cmd}, nil
+}
+
+// Unseals Vault if the Shamir seal is used, e.g., after a restart or reinstallation.
+// The unseal keys are the configured ones or the keys of the initialization, unless they are PGP-encrypted.
+// The unseal runs whenever the installation restarts Vault and fails if Vault is sealed and cannot be unsealed.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// vaultInstall: The Vault installation command.
+// keys: The Vault keys output of the initialization.
+// vaultConfig: Vault configuration.
+// dependsOn: Pulumi resource option to specify dependencies.
+func unseal(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	vaultInstall *remote.Command,
+	keys *pulumi.AnyOutput,
+	vaultConfig *vaultConf.Config,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) ([]pulumi.Resource, error) {
+	if sealType(vaultConfig.Seal) != sealTypeShamir {
+		return nil, nil
+	}
+
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	script, sErr := file.ReadContents("./assets/vault/unseal.sh")
+	if sErr != nil {
+		return nil, sErr
+	}
+
+	encrypted := vaultConfig.Bootstrap != nil && len(vaultConfig.Bootstrap.RecoveryPGPKeys) > 0
+	unsealKeys, _ := keys.ApplyT(func(k any) string {
+		if len(vaultConfig.Seal.UnsealKeys) > 0 {
+			return strings.Join(vaultConfig.Seal.UnsealKeys, "\n")
+		}
+		if encrypted {
+			return ""
+		}
+		return strings.Join(k.(*vault.Keys).RecoveryKeys, "\n")
+	}).(pulumi.StringOutput)
+
+	// Vault is sealed after every restart, hence the unseal follows the changes restarting it
+	cmd, cErr := remote.NewCommand(ctx, "vault-unseal", &remote.CommandArgs{
+		Create:     pulumi.StringPtr(script),
+		Stdin:      pulumi.ToSecret(unsealKeys).(pulumi.StringOutput),
+		Triggers:   vaultInstall.Triggers,
+		Connection: conn,
+	}, dependsOn, pulumi.Timeouts(&pulumi.CustomTimeouts{
+		Create: "20m",
+	}))
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	return []pulumi.Resource{cmd}, nil
+}
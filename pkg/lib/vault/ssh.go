@@ -0,0 +1,38 @@
+package vault
+
+import (
+	"strings"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// Trusts the SSH certificate authority on the server to accept user certificates signed by Vault.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// caPublicKey: The public key of the SSH certificate authority.
+func trustSSHCA(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	caPublicKey pulumi.StringOutput,
+) (*remote.Command, error) {
+	script, _ := caPublicKey.ApplyT(func(publicKey string) (string, error) {
+		return template.Render("./assets/vault/trust-ssh-ca.sh.j2", map[string]any{
+			"publicKey": strings.TrimSpace(publicKey),
+		})
+	}).(pulumi.StringOutput)
+
+	return remote.NewCommand(ctx, "remote-command-trust-vault-ssh-ca", &remote.CommandArgs{
+		Create:     script,
+		Update:     script,
+		Triggers:   pulumi.Array{script},
+		Connection: install.Connection(sshIPv4, privateKeyPem, proxy),
+	})
+}
@@ -0,0 +1,67 @@
+package volume
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/template"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/server"
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/util/install"
+)
+
+// Install formats and mounts the volumes of the server via SSH.
+// Existing data at the mount path is moved onto an empty volume.
+// The volumes are mounted one after another by mount path to mount nested paths in order.
+// ctx: Pulumi context.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
+// volumes: The volumes attached to the server.
+// dependsOn: Pulumi resource option to specify dependencies.
+func Install(
+	ctx *pulumi.Context,
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+	volumes []*server.Volume,
+	dependsOn pulumi.ResourceOrInvokeOption,
+) ([]pulumi.Resource, error) {
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
+
+	sorted := slices.SortedFunc(slices.Values(volumes), func(a *server.Volume, b *server.Volume) int {
+		return cmp.Compare(a.MountPath, b.MountPath)
+	})
+
+	resources := make([]pulumi.Resource, 0, len(volumes))
+	for _, volume := range sorted {
+		mountFn, _ := volume.Device.ApplyT(func(device string) string {
+			tpl, _ := template.Render("./assets/volume/mount.sh.j2", map[string]any{
+				"name":      volume.Name,
+				"device":    device,
+				"mountPath": volume.MountPath,
+				"format":    volume.Format,
+			})
+			return tpl
+		}).(pulumi.StringOutput)
+
+		mount, mErr := remote.NewCommand(ctx, fmt.Sprintf("remote-command-mount-volume-%s", volume.Name),
+			&remote.CommandArgs{
+				Create:     mountFn,
+				Update:     mountFn,
+				Triggers:   pulumi.Array{mountFn},
+				Connection: conn,
+			},
+			dependsOn,
+			pulumi.DependsOn(append([]pulumi.Resource{volume.Resource}, resources...)),
+		)
+		if mErr != nil {
+			return nil, mErr
+		}
+		resources = append(resources, mount)
+	}
+	return resources, nil
+}
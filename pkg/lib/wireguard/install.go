@@ -16,6 +16,7 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // wireguardData: WireGuard configuration data.
 // dnsConfig: DNS configuration.
 // dependsOn: Pulumi resource option to specify dependencies.
@@ -23,15 +24,12 @@ func installer(
 	ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	wireguardData *wireguardData.Data,
 	dnsConfig *dns.Config,
 	dependsOn pulumi.ResourceOrInvokeOption,
 ) (*remote.Command, error) {
-	conn := &remote.ConnectionArgs{
-		Host:       sshIPv4,
-		PrivateKey: privateKeyPem,
-		User:       pulumi.String("root"),
-	}
+	conn := install.Connection(sshIPv4, privateKeyPem, proxy)
 
 	opts := []pulumi.ResourceOption{dependsOn}
 
@@ -52,7 +50,7 @@ func installer(
 			return *hash
 		})
 	dockerComposeCopy := dockerComposeHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := install.CopyToRemote(
 			ctx,
 			"remote-copy-wireguard-docker-compose",
 			&remote.CopyToRemoteArgs{
@@ -61,13 +59,14 @@ func installer(
 				Triggers:   pulumi.Array{dockerComposeHash},
 				Connection: conn,
 			},
+			0o644,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
 	configResources, configHashes := createConfigs(ctx, wireguardData, dnsConfig, conn, opts...)
 
-	cronResources, cronErr := install.Cron(ctx, "wireguard", conn, opts...)
+	cronResources, cronErr := install.Cron(ctx, "wireguard", conn, nil, opts...)
 	if cronErr != nil {
 		return nil, cronErr
 	}
@@ -117,6 +116,7 @@ func createConfigs(
 		tpl, _ := template.Render("./assets/wireguard/config.yml.j2", map[string]any{
 			"domain":        dnsConfig.Entries["wireguard"].Domain,
 			"adminPassword": adminPassword,
+			"clientCidrV4":  ClientCIDRv4,
 			"database": map[string]string{
 				"encryptionPassphrase": encryptionPassphrase,
 			},
@@ -138,12 +138,12 @@ func createConfigs(
 			return *hash
 		})
 	wireguardConfigCopy := wireguardConfigHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(ctx, "remote-copy-wireguard-config", &remote.CopyToRemoteArgs{
+		cmd, _ := install.CopyToRemote(ctx, "remote-copy-wireguard-config", &remote.CopyToRemoteArgs{
 			Source:     pulumi.NewFileAsset("./outputs/wireguard_config.yml"),
 			RemotePath: pulumi.String("/opt/wireguard/config/config.yml"),
 			Triggers:   pulumi.Array{wireguardConfigHash},
 			Connection: conn,
-		}, opts...)
+		}, 0o644, opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
 
@@ -13,12 +13,14 @@ import (
 // ctx: Pulumi context.
 // sshIPv4: The IPv4 address of the server to connect to via SSH.
 // privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connections through (optional).
 // dnsConfig: DNS configuration.
 // oidcConfig: OIDC configuration.
 // dependsOn: List of Pulumi resources that this installation depends on.
 func Install(ctx *pulumi.Context,
 	sshIPv4 pulumi.StringOutput,
 	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
 	dnsConfig *dns.Config,
 	oidcConfig *oidc.Config,
 	dependsOn []pulumi.Resource,
@@ -31,6 +33,7 @@ func Install(ctx *pulumi.Context,
 		ctx,
 		sshIPv4,
 		privateKeyPem,
+		proxy,
 		wireguardData,
 		dnsConfig,
 		pulumi.DependsOn(dependsOn),
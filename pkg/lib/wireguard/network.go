@@ -0,0 +1,4 @@
+package wireguard
+
+// ClientCIDRv4 is the IPv4 network the WireGuard portal assigns to the clients.
+const ClientCIDRv4 = "10.11.12.0/24"
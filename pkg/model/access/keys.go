@@ -0,0 +1,11 @@
+package access
+
+import "github.com/pulumi/pulumi-tls/sdk/v5/go/tls"
+
+// Keys holds the SSH keys of the deploy user.
+type Keys struct {
+	// Current is the key of the current generation, used by all connections.
+	Current *tls.PrivateKey
+	// Previous is the key of the previous generation while rotating (optional).
+	Previous *tls.PrivateKey
+}
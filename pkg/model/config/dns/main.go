@@ -8,12 +8,44 @@ type Config struct {
 	Email *string `yaml:"email,omitempty"`
 	// Entries are the DNS entries.
 	Entries map[string]EntryConfig `yaml:"entries,omitempty"`
+	// Zones are the DNS provider settings per zone identifier.
+	Zones map[string]*ZoneConfig `yaml:"zones,omitempty"`
+}
+
+// ZoneConfig defines configuration data for a DNS zone.
+type ZoneConfig struct {
+	// Provider is the DNS provider of the zone, one of google, scaleway (optional, default: "google").
+	Provider *string `yaml:"provider,omitempty"`
+	// Domain is the domain of the zone (optional, default: the zone identifier).
+	Domain *string `yaml:"domain,omitempty"`
+	// CAA restricts the certificate authorities allowed to issue certificates for the zone (optional).
+	CAA *CAAConfig `yaml:"caa,omitempty"`
+	// DNSSEC enables DNSSEC on the zone; Google Cloud DNS only (optional, default: false).
+	DNSSEC *bool `yaml:"dnssec,omitempty"`
+}
+
+// CAAConfig defines configuration data for the CAA records of a zone.
+type CAAConfig struct {
+	// Issuers are additional certificate authorities allowed to issue certificates (optional).
+	Issuers []string `yaml:"issuers,omitempty"`
+	// IODEF is the URL to report policy violations to, e.g., mailto:security@example.com (optional).
+	IODEF *string `yaml:"iodef,omitempty"`
 }
 
 // EntryConfig defines configuration data for a DNS entry.
 type EntryConfig struct {
 	// Domain is the DNS entry domain.
 	Domain *string `yaml:"domain,omitempty"`
-	// ZoneID is the DNS entry zone ID.
+	// ZoneID is the DNS entry zone ID (the managed zone name for Google, the zone domain for Scaleway).
 	ZoneID *string `yaml:"zoneId,omitempty"`
+	// Type is the record type (optional, default: A and AAAA for the public target, A for the private target).
+	Type *string `yaml:"type,omitempty"`
+	// TTL is the time to live of the record in seconds (optional, default: 300).
+	TTL *int `yaml:"ttl,omitempty"`
+	// Target is the server address the record points to, one of public, private (optional, default: "public").
+	Target *string `yaml:"target,omitempty"`
+	// Values are literal record values, taking precedence over the target (optional).
+	Values []string `yaml:"values,omitempty"`
+	// Service is the name of the Traefik route served on the domain (optional).
+	Service *string `yaml:"service,omitempty"`
 }
@@ -0,0 +1,27 @@
+package network
+
+// HostFirewallConfig defines configuration data for the host (nftables) firewall.
+type HostFirewallConfig struct {
+	// Zones are the filtered zones, one of public, private, wireguard, gre, tailscale.
+	Zones map[string]*HostFirewallZoneConfig `yaml:"zones,omitempty"`
+	// Forward are the zone pairs traffic is allowed to be forwarded between.
+	Forward []*HostFirewallForwardConfig `yaml:"forward,omitempty"`
+}
+
+// HostFirewallZoneConfig defines configuration data for a zone of the host firewall.
+type HostFirewallZoneConfig struct {
+	// Interfaces are the interface names of the zone; a trailing * matches any suffix (optional).
+	Interfaces []string `yaml:"interfaces,omitempty"`
+	// Policy is the policy for inbound traffic not matching any rule.
+	Policy *string `yaml:"policy,omitempty"` // 'accept' | 'drop'
+	// FirewallRules are the names of the inbound firewall rules allowed in the zone.
+	FirewallRules []string `yaml:"firewallRules,omitempty"`
+}
+
+// HostFirewallForwardConfig defines configuration data for forwarding between zones of the host firewall.
+type HostFirewallForwardConfig struct {
+	// From is the zone traffic is forwarded from.
+	From *string `yaml:"from,omitempty"`
+	// To is the zone traffic is forwarded to.
+	To *string `yaml:"to,omitempty"`
+}
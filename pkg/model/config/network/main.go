@@ -4,24 +4,46 @@ package network
 type Config struct {
 	// Name is the name of the network.
 	Name *string `yaml:"name,omitempty"`
+	// Selector is the label selector to look up an existing network with (optional, default: the name).
+	Selector *string `yaml:"selector,omitempty"`
 	// DNSSuffix is the DNS suffix for the network.
 	DNSSuffix *string `yaml:"dnsSuffix,omitempty"`
 	// CIDR is the CIDR block for the network.
 	CIDR *string `yaml:"cidr,omitempty"`
 	// SubnetCIDR is the CIDR block for the subnet.
 	SubnetCIDR *string `yaml:"subnetCidr,omitempty"`
+	// ExistingSubnet indicates if the subnet exists in the shared network and is imported (optional, default: false).
+	ExistingSubnet *bool `yaml:"existingSubnet,omitempty"`
+	// Routes configures the routes of the network via the server (optional).
+	Routes *RoutesConfig `yaml:"routes,omitempty"`
 	// FirewallRules are the firewall rules for the network.
 	FirewallRules map[string]*FirewallRule `yaml:"firewallRules,omitempty"`
+	// HostFirewall is the host (nftables) firewall configuration (optional).
+	HostFirewall *HostFirewallConfig `yaml:"hostFirewall,omitempty"`
+}
+
+// RoutesConfig defines configuration data for the routes of the network via the server.
+type RoutesConfig struct {
+	// Enabled indicates if the routes are created (optional, default: true).
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Destinations are additional destinations to route via the server (optional).
+	Destinations []string `yaml:"destinations,omitempty"`
 }
 
 // FirewallRule defines a firewall rule.
 type FirewallRule struct {
 	// Description is the description of the firewall rule.
 	Description *string `yaml:"description,omitempty"`
-	// Port is the port of the firewall rule.
+	// Direction is the direction of the firewall rule.
+	Direction *string `yaml:"direction,omitempty"` // 'in' | 'out'
+	// Port is the port, or the first port of the port range, of the firewall rule.
 	Port *int `yaml:"port,omitempty"`
+	// PortEnd is the last port of the port range of the firewall rule.
+	PortEnd *int `yaml:"portEnd,omitempty"`
 	// Protocol is the protocol of the firewall rule.
-	Protocol *string `yaml:"protocol,omitempty"` // 'tcp' | 'udp' | 'icmp'
+	Protocol *string `yaml:"protocol,omitempty"` // 'tcp' | 'udp' | 'icmp' | 'gre' | 'esp'
 	// SourceIPs are the source IPs of the firewall rule.
 	SourceIPs []string `yaml:"sourceIPs,omitempty"`
+	// DestinationIPs are the destination IPs of the firewall rule.
+	DestinationIPs []string `yaml:"destinationIPs,omitempty"`
 }
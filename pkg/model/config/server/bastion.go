@@ -0,0 +1,14 @@
+package server
+
+// BastionConfig defines configuration data for the jump host all SSH connections are proxied through.
+// The private key to authenticate at the bastion with is read from the secret `bastionPrivateKey`.
+type BastionConfig struct {
+	// Host is the address of the bastion, e.g. another Hetzner server or a Tailscale address.
+	Host *string `yaml:"host,omitempty"`
+	// Port is the SSH port of the bastion (optional, default: 22).
+	Port *int `yaml:"port,omitempty"`
+	// User is the user to connect to the bastion with (optional, default: "root").
+	User *string `yaml:"user,omitempty"`
+	// HostKey is the expected host public key of the bastion (optional).
+	HostKey *string `yaml:"hostKey,omitempty"`
+}
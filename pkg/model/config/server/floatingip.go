@@ -0,0 +1,23 @@
+package server
+
+// FloatingIPConfig defines configuration data for the floating IPs of the server.
+type FloatingIPConfig struct {
+	// IPv4ID is the ID of an existing floating IPv4 shared with other core servers (optional, default: created).
+	IPv4ID *int `yaml:"ipv4Id,omitempty"`
+	// IPv6ID is the ID of an existing floating IPv6 shared with other core servers (optional, default: created).
+	IPv6ID *int `yaml:"ipv6Id,omitempty"`
+	// Failover configures the failover of the floating IPs between core servers (optional).
+	Failover *FailoverConfig `yaml:"failover,omitempty"`
+}
+
+// FailoverConfig defines configuration data for the failover of the floating IPs between core servers.
+type FailoverConfig struct {
+	// Peers are the private IPv4 addresses of the other core servers.
+	Peers []string `yaml:"peers,omitempty"`
+	// Priority is the VRRP priority; the healthy server with the highest priority holds the IPs (optional, default: 100).
+	Priority *int `yaml:"priority,omitempty"`
+	// RouterID is the VRRP virtual router ID shared by the core servers (optional, default: 51).
+	RouterID *int `yaml:"routerId,omitempty"`
+	// Services are the systemd services checked for the health of the server (optional, default: traefik, wireguard).
+	Services []string `yaml:"services,omitempty"`
+}
@@ -10,4 +10,18 @@ type Config struct {
 	IPv4 *string `yaml:"ipv4,omitempty"`
 	// PublicSSH indicates if public SSH access is enabled.
 	PublicSSH *bool `yaml:"publicSsh,omitempty"`
+	// Bastion is the jump host to proxy the SSH connections through (optional).
+	Bastion *BastionConfig `yaml:"bastion,omitempty"`
+	// ReverseDNS is the PTR name of the public IPs (optional, default: the hostname within the network DNS suffix).
+	ReverseDNS *string `yaml:"reverseDns,omitempty"`
+	// SSHKeyGeneration is the generation of the SSH key; increase it to rotate the key (optional, default: 0).
+	SSHKeyGeneration *int `yaml:"sshKeyGeneration,omitempty"`
+	// FloatingIPs configures the floating IPs of the server (optional).
+	FloatingIPs *FloatingIPConfig `yaml:"floatingIps,omitempty"`
+	// Backups enables the automatic Hetzner backups of the server (optional, default: false).
+	Backups *bool `yaml:"backups,omitempty"`
+	// Snapshots configures scheduled snapshots of the server (optional).
+	Snapshots *SnapshotConfig `yaml:"snapshots,omitempty"`
+	// Volumes are the Hetzner volumes to attach to the server (optional).
+	Volumes map[string]*VolumeConfig `yaml:"volumes,omitempty"`
 }
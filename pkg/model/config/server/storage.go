@@ -0,0 +1,17 @@
+package server
+
+// SnapshotConfig defines configuration data for scheduled snapshots of the server.
+type SnapshotConfig struct {
+	// Retention is the number of snapshots to keep (optional, default: 7).
+	Retention *int `yaml:"retention,omitempty"`
+}
+
+// VolumeConfig defines configuration data for a Hetzner volume.
+type VolumeConfig struct {
+	// Size is the size of the volume in GB.
+	Size *int `yaml:"size,omitempty"`
+	// Format is the file system of the volume, one of ext4, xfs (optional, default: "ext4").
+	Format *string `yaml:"format,omitempty"`
+	// MountPath is the path to mount the volume at (optional, default: "/opt").
+	MountPath *string `yaml:"mountPath,omitempty"`
+}
@@ -0,0 +1,7 @@
+package traefik
+
+// AccessLogConfig defines configuration data for the JSON access logs.
+type AccessLogConfig struct {
+	// RetentionDays is the number of days access logs are retained in the backup bucket (optional, default: 30).
+	RetentionDays *int `yaml:"retentionDays,omitempty"`
+}
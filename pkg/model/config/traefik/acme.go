@@ -0,0 +1,29 @@
+package traefik
+
+// ACMEConfig defines configuration data for the ACME certificate resolvers.
+type ACMEConfig struct {
+	// Staging uses the Let's Encrypt staging CA, e.g., for non-production stacks (optional, default: false).
+	Staging *bool `yaml:"staging,omitempty"`
+	// Resolvers are the named certificate resolvers in addition to the default "letsencrypt" resolver.
+	Resolvers map[string]*CertResolverConfig `yaml:"resolvers,omitempty"`
+	// Wildcards are the named wildcard certificates requested on startup.
+	Wildcards map[string]*WildcardConfig `yaml:"wildcards,omitempty"`
+}
+
+// CertResolverConfig defines configuration data for a certificate resolver.
+type CertResolverConfig struct {
+	// Challenge is the ACME challenge, one of dns, http, tlsalpn (optional, default: "dns").
+	Challenge *string `yaml:"challenge,omitempty"`
+	// Provider is the DNS provider of the DNS challenge, one of gcloud, scaleway (optional, default: "gcloud").
+	Provider *string `yaml:"provider,omitempty"`
+	// KeyType is the type of the certificate key (optional, default: "EC256").
+	KeyType *string `yaml:"keyType,omitempty"`
+}
+
+// WildcardConfig defines configuration data for a wildcard certificate.
+type WildcardConfig struct {
+	// Domain is the domain to request the certificate for, covering the domain and its subdomains.
+	Domain *string `yaml:"domain,omitempty"`
+	// Resolver is the name of the certificate resolver, requiring a DNS challenge (optional, default: "letsencrypt").
+	Resolver *string `yaml:"resolver,omitempty"`
+}
@@ -0,0 +1,23 @@
+package traefik
+
+// EntryPointsConfig defines configuration data for the entrypoint policy.
+type EntryPointsConfig struct {
+	// Redirect redirects HTTP to HTTPS on the web entrypoint (optional, default: false).
+	Redirect *bool `yaml:"redirect,omitempty"`
+	// TrustedIPs are the IPs trusted to send the PROXY protocol header (optional, default: loopback and RFC1918).
+	TrustedIPs []string `yaml:"trustedIps,omitempty"`
+	// TLS are the default TLS options applied to all routers without explicit TLS options (optional).
+	TLS *TLSOptionConfig `yaml:"tls,omitempty"`
+	// HSTS sets the Strict-Transport-Security header on the HTTPS entrypoints (optional).
+	HSTS *HSTSConfig `yaml:"hsts,omitempty"`
+}
+
+// HSTSConfig defines configuration data for HTTP Strict Transport Security.
+type HSTSConfig struct {
+	// Seconds is the max-age of the header (optional, default: 31536000).
+	Seconds *int `yaml:"seconds,omitempty"`
+	// IncludeSubdomains adds includeSubDomains to the header (optional, default: true).
+	IncludeSubdomains *bool `yaml:"includeSubdomains,omitempty"`
+	// Preload adds preload to the header (optional, default: false).
+	Preload *bool `yaml:"preload,omitempty"`
+}
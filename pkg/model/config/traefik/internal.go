@@ -0,0 +1,15 @@
+package traefik
+
+// InternalConfig defines configuration data for the internal entrypoint serving the dashboard and metrics.
+type InternalConfig struct {
+	// Address is the host address to bind to, e.g., the Tailscale IP (optional, default: server private IPv4).
+	Address *string `yaml:"address,omitempty"`
+	// Port is the port of the entrypoint (optional, default: 8080).
+	Port *int `yaml:"port,omitempty"`
+	// Domain is the domain the dashboard and metrics are served on.
+	Domain *string `yaml:"domain,omitempty"`
+	// Dashboard enables the API dashboard (optional, default: false).
+	Dashboard *bool `yaml:"dashboard,omitempty"`
+	// Metrics enables the Prometheus metrics (optional, default: false).
+	Metrics *bool `yaml:"metrics,omitempty"`
+}
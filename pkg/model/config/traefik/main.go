@@ -0,0 +1,21 @@
+package traefik
+
+// Config defines configuration data for Traefik.
+type Config struct {
+	// ACME are the certificate resolvers and wildcard certificates.
+	ACME *ACMEConfig `yaml:"acme,omitempty"`
+	// EntryPoints is the entrypoint policy (redirection, TLS hardening, HSTS, PROXY protocol).
+	EntryPoints *EntryPointsConfig `yaml:"entryPoints,omitempty"`
+	// Routes are the routes to services outside of Docker or on other hosts.
+	Routes map[string]*RouteConfig `yaml:"routes,omitempty"`
+	// Middlewares are the named middlewares routes can refer to.
+	Middlewares map[string]*MiddlewareConfig `yaml:"middlewares,omitempty"`
+	// SSO is the single sign-on proxy configuration used by forward-auth middlewares.
+	SSO *SSOConfig `yaml:"sso,omitempty"`
+	// TLSOptions are the named TLS options routes can refer to.
+	TLSOptions map[string]*TLSOptionConfig `yaml:"tlsOptions,omitempty"`
+	// Internal is the internal entrypoint serving the dashboard and metrics.
+	Internal *InternalConfig `yaml:"internal,omitempty"`
+	// AccessLog enables the JSON access logs shipped to the backup bucket.
+	AccessLog *AccessLogConfig `yaml:"accessLog,omitempty"`
+}
@@ -0,0 +1,65 @@
+package traefik
+
+// MiddlewareConfig defines configuration data for a middleware; exactly one type must be set.
+type MiddlewareConfig struct {
+	// ForwardAuth authenticates requests via single sign-on (oauth2-proxy) against the OIDC provider (optional).
+	ForwardAuth *ForwardAuthConfig `yaml:"forwardAuth,omitempty"`
+	// IPAllowList restricts access to source IP ranges (optional).
+	IPAllowList *IPAllowListConfig `yaml:"ipAllowList,omitempty"`
+	// RateLimit limits the request rate (optional).
+	RateLimit *RateLimitConfig `yaml:"rateLimit,omitempty"`
+	// Headers sets security headers (optional).
+	Headers *HeadersConfig `yaml:"headers,omitempty"`
+	// BasicAuth authenticates requests with generated credentials stored in Vault (optional).
+	BasicAuth *BasicAuthConfig `yaml:"basicAuth,omitempty"`
+}
+
+// ForwardAuthConfig defines configuration data for a forward-auth middleware.
+type ForwardAuthConfig struct {
+	// AuthResponseHeaders are the headers copied from the authentication response (optional).
+	AuthResponseHeaders []string `yaml:"authResponseHeaders,omitempty"`
+}
+
+// IPAllowListConfig defines configuration data for an IP allowlist middleware.
+type IPAllowListConfig struct {
+	// SourceRanges are the allowed source IP ranges (optional).
+	SourceRanges []string `yaml:"sourceRanges,omitempty"`
+	// FirewallRules are the names of network firewall rules whose source IPs are allowed (optional).
+	FirewallRules []string `yaml:"firewallRules,omitempty"`
+}
+
+// RateLimitConfig defines configuration data for a rate limit middleware.
+type RateLimitConfig struct {
+	// Average is the average number of requests per period.
+	Average *int `yaml:"average,omitempty"`
+	// Burst is the maximum number of requests in a burst (optional).
+	Burst *int `yaml:"burst,omitempty"`
+	// Period is the period of the rate, e.g., 1m (optional, default: "1s").
+	Period *string `yaml:"period,omitempty"`
+}
+
+// HeadersConfig defines configuration data for a security headers middleware.
+type HeadersConfig struct {
+	// STSSeconds is the max-age of the Strict-Transport-Security header (optional).
+	STSSeconds *int `yaml:"stsSeconds,omitempty"`
+	// STSIncludeSubdomains adds includeSubDomains to the Strict-Transport-Security header (optional).
+	STSIncludeSubdomains *bool `yaml:"stsIncludeSubdomains,omitempty"`
+	// STSPreload adds preload to the Strict-Transport-Security header (optional).
+	STSPreload *bool `yaml:"stsPreload,omitempty"`
+	// FrameDeny sets X-Frame-Options to DENY (optional).
+	FrameDeny *bool `yaml:"frameDeny,omitempty"`
+	// ContentTypeNosniff sets X-Content-Type-Options to nosniff (optional).
+	ContentTypeNosniff *bool `yaml:"contentTypeNosniff,omitempty"`
+	// ReferrerPolicy is the Referrer-Policy header (optional).
+	ReferrerPolicy *string `yaml:"referrerPolicy,omitempty"`
+	// ContentSecurityPolicy is the Content-Security-Policy header (optional).
+	ContentSecurityPolicy *string `yaml:"contentSecurityPolicy,omitempty"`
+	// CustomResponseHeaders are additional response headers (optional).
+	CustomResponseHeaders map[string]string `yaml:"customResponseHeaders,omitempty"`
+}
+
+// BasicAuthConfig defines configuration data for a basic-auth middleware.
+type BasicAuthConfig struct {
+	// Users are the users to generate credentials for.
+	Users []string `yaml:"users,omitempty"`
+}
@@ -0,0 +1,29 @@
+package traefik
+
+// RouteConfig defines configuration data for a route (router and service).
+type RouteConfig struct {
+	// Rule is the router rule, e.g., Host(`example.com`) (optional, default: the domains of the DNS entries).
+	Rule *string `yaml:"rule,omitempty"`
+	// EntryPoints are the entrypoints the router listens on (optional, default: ["websecure"]).
+	EntryPoints []string `yaml:"entryPoints,omitempty"`
+	// Priority is the priority of the router (optional).
+	Priority *int `yaml:"priority,omitempty"`
+	// URLs are the upstream URLs of the service, e.g., on the private network or Tailscale.
+	URLs []string `yaml:"urls,omitempty"`
+	// PassHostHeader forwards the client Host header to the upstream (optional, default: true).
+	PassHostHeader *bool `yaml:"passHostHeader,omitempty"`
+	// InsecureSkipVerify disables the TLS certificate verification of HTTPS upstreams (optional, default: false).
+	InsecureSkipVerify *bool `yaml:"insecureSkipVerify,omitempty"`
+	// Middlewares are the names of the middlewares applied to the router (optional).
+	Middlewares []string `yaml:"middlewares,omitempty"`
+	// TLS is the TLS configuration of the router (optional).
+	TLS *RouteTLSConfig `yaml:"tls,omitempty"`
+}
+
+// RouteTLSConfig defines the TLS configuration of a route.
+type RouteTLSConfig struct {
+	// CertResolver is the certificate resolver (optional, default: "letsencrypt").
+	CertResolver *string `yaml:"certResolver,omitempty"`
+	// Options is the name of the TLS options (optional).
+	Options *string `yaml:"options,omitempty"`
+}
@@ -0,0 +1,13 @@
+package traefik
+
+// SSOConfig defines configuration data for the single sign-on proxy (oauth2-proxy) used by forward-auth middlewares.
+type SSOConfig struct {
+	// Domain is the domain the proxy is served on, e.g., auth.example.com.
+	Domain *string `yaml:"domain,omitempty"`
+	// CookieDomain is the domain of the session cookie, e.g., .example.com.
+	CookieDomain *string `yaml:"cookieDomain,omitempty"`
+	// EmailDomains are the allowed email domains (optional, default: ["*"]).
+	EmailDomains []string `yaml:"emailDomains,omitempty"`
+	// Client is the name of the OIDC client in the OIDC configuration (optional, default: "traefik").
+	Client *string `yaml:"client,omitempty"`
+}
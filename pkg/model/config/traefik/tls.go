@@ -0,0 +1,15 @@
+package traefik
+
+// TLSOptionConfig defines configuration data for TLS options.
+type TLSOptionConfig struct {
+	// MinVersion is the minimum TLS version, e.g., VersionTLS12 (optional).
+	MinVersion *string `yaml:"minVersion,omitempty"`
+	// MaxVersion is the maximum TLS version (optional).
+	MaxVersion *string `yaml:"maxVersion,omitempty"`
+	// CipherSuites are the allowed cipher suites for TLS 1.2 and below (optional).
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// CurvePreferences are the elliptic curves in preference order (optional).
+	CurvePreferences []string `yaml:"curvePreferences,omitempty"`
+	// SniStrict rejects connections without a matching SNI (optional, default: false).
+	SniStrict *bool `yaml:"sniStrict,omitempty"`
+}
@@ -0,0 +1,27 @@
+package vault
+
+// AuditConfig defines configuration data for the Vault audit devices.
+type AuditConfig struct {
+	// RetentionDays is the number of days audit logs are retained in the backup bucket (optional, default: 90).
+	RetentionDays *int `yaml:"retentionDays,omitempty"`
+	// Syslog is the syslog audit device configuration (optional).
+	Syslog *AuditSyslogConfig `yaml:"syslog,omitempty"`
+	// Socket is the socket audit device configuration (optional).
+	Socket *AuditSocketConfig `yaml:"socket,omitempty"`
+}
+
+// AuditSyslogConfig defines configuration data for a syslog audit device.
+type AuditSyslogConfig struct {
+	// Facility is the syslog facility (optional, default: "AUTH").
+	Facility *string `yaml:"facility,omitempty"`
+	// Tag is the syslog tag (optional, default: "vault").
+	Tag *string `yaml:"tag,omitempty"`
+}
+
+// AuditSocketConfig defines configuration data for a socket audit device.
+type AuditSocketConfig struct {
+	// Address is the socket server address.
+	Address *string `yaml:"address,omitempty"`
+	// SocketType is the socket type (optional, default: "tcp").
+	SocketType *string `yaml:"socketType,omitempty"`
+}
@@ -0,0 +1,13 @@
+package vault
+
+// BootstrapConfig defines configuration data for bootstrapping the Vault provider credentials.
+type BootstrapConfig struct {
+	// KeepRootToken keeps the initial root token instead of revoking it after bootstrapping (optional, default: false).
+	KeepRootToken *bool `yaml:"keepRootToken,omitempty"`
+	// RecoveryPGPKeys are the base64-encoded PGP public keys used to encrypt the recovery keys (optional).
+	RecoveryPGPKeys []string `yaml:"recoveryPgpKeys,omitempty"`
+	// RootToken is a root token generated with the generate-root flow to re-run the bootstrap (optional).
+	RootToken *string `yaml:"rootToken,omitempty"`
+	// SecretIDTTL is the TTL of the AppRole secret ID used by the Pulumi provider (optional, default: 0, no expiry).
+	SecretIDTTL *string `yaml:"secretIdTtl,omitempty"`
+}
@@ -0,0 +1,93 @@
+package vault
+
+// SecretsEnginesConfig defines configuration data for the Vault secrets engines.
+type SecretsEnginesConfig struct {
+	// KV are the KV v2 secrets engines keyed by their mount path.
+	KV map[string]*KVConfig `yaml:"kv,omitempty"`
+	// PKI is the two-tier PKI secrets engine configuration.
+	PKI *PKIConfig `yaml:"pki,omitempty"`
+	// Transit is the transit secrets engine configuration.
+	Transit *TransitConfig `yaml:"transit,omitempty"`
+	// SSH is the SSH certificate authority secrets engine configuration.
+	SSH *SSHConfig `yaml:"ssh,omitempty"`
+}
+
+// KVConfig defines configuration data for a KV v2 secrets engine.
+type KVConfig struct {
+	// Description is the description of the mount.
+	Description *string `yaml:"description,omitempty"`
+}
+
+// PKIConfig defines configuration data for a two-tier PKI (root and intermediate CA).
+type PKIConfig struct {
+	// CommonName is the common name of the CA certificates.
+	CommonName *string `yaml:"commonName,omitempty"`
+	// Organization is the organization of the CA certificates.
+	Organization *string `yaml:"organization,omitempty"`
+	// KeyType is the key type of the CA certificates (optional, default: "ec").
+	KeyType *string `yaml:"keyType,omitempty"`
+	// KeyBits is the number of bits of the CA keys (optional, default: 384).
+	KeyBits *int `yaml:"keyBits,omitempty"`
+	// RootTTL is the TTL of the root CA certificate (optional, default: "87600h").
+	RootTTL *string `yaml:"rootTtl,omitempty"`
+	// IntermediateTTL is the TTL of the intermediate CA certificate (optional, default: "43800h").
+	IntermediateTTL *string `yaml:"intermediateTtl,omitempty"`
+	// Roles are the PKI roles issued by the intermediate CA.
+	Roles map[string]*PKIRoleConfig `yaml:"roles,omitempty"`
+}
+
+// PKIRoleConfig defines configuration data for a PKI role.
+type PKIRoleConfig struct {
+	// AllowedDomains are the domains the role is allowed to issue certificates for.
+	AllowedDomains []string `yaml:"allowedDomains,omitempty"`
+	// AllowBareDomains indicates if the bare domains may be issued.
+	AllowBareDomains bool `yaml:"allowBareDomains,omitempty"`
+	// AllowSubdomains indicates if subdomains of the allowed domains may be issued.
+	AllowSubdomains bool `yaml:"allowSubdomains,omitempty"`
+	// ServerFlag indicates if certificates are flagged for server use (optional, default: true).
+	ServerFlag *bool `yaml:"serverFlag,omitempty"`
+	// ClientFlag indicates if certificates are flagged for client use (optional, default: true).
+	ClientFlag *bool `yaml:"clientFlag,omitempty"`
+	// TTL is the default TTL of issued certificates.
+	TTL *string `yaml:"ttl,omitempty"`
+	// MaxTTL is the maximum TTL of issued certificates.
+	MaxTTL *string `yaml:"maxTtl,omitempty"`
+}
+
+// TransitConfig defines configuration data for the transit secrets engine.
+type TransitConfig struct {
+	// Keys are the named transit keys.
+	Keys map[string]*TransitKeyConfig `yaml:"keys,omitempty"`
+}
+
+// TransitKeyConfig defines configuration data for a transit key.
+type TransitKeyConfig struct {
+	// Type is the key type (optional, default: "aes256-gcm96").
+	Type *string `yaml:"type,omitempty"`
+	// Exportable indicates if the key may be exported.
+	Exportable bool `yaml:"exportable,omitempty"`
+	// DeletionAllowed indicates if the key may be deleted.
+	DeletionAllowed bool `yaml:"deletionAllowed,omitempty"`
+	// AutoRotatePeriod is the automatic rotation period in seconds (optional, 0 disables rotation).
+	AutoRotatePeriod *int `yaml:"autoRotatePeriod,omitempty"`
+}
+
+// SSHConfig defines configuration data for the SSH certificate authority secrets engine.
+type SSHConfig struct {
+	// KeyType is the key type of the CA signing key (optional, default: "ed25519").
+	KeyType *string `yaml:"keyType,omitempty"`
+	// Roles are the SSH roles signing user certificates.
+	Roles map[string]*SSHRoleConfig `yaml:"roles,omitempty"`
+}
+
+// SSHRoleConfig defines configuration data for an SSH role.
+type SSHRoleConfig struct {
+	// AllowedUsers are the users certificates may be signed for.
+	AllowedUsers []string `yaml:"allowedUsers,omitempty"`
+	// DefaultUser is the default user of signed certificates.
+	DefaultUser *string `yaml:"defaultUser,omitempty"`
+	// TTL is the default TTL of signed certificates (optional, default: "30m").
+	TTL *string `yaml:"ttl,omitempty"`
+	// MaxTTL is the maximum TTL of signed certificates (optional, default: "1h").
+	MaxTTL *string `yaml:"maxTtl,omitempty"`
+}
@@ -0,0 +1,9 @@
+package vault
+
+// InitConfig defines configuration data for the Vault initialization.
+type InitConfig struct {
+	// RecoveryShares is the number of recovery key shares (optional, default: 5).
+	RecoveryShares *int `yaml:"recoveryShares,omitempty"`
+	// RecoveryThreshold is the number of recovery key shares required to reconstruct the root key (optional, default: 3).
+	RecoveryThreshold *int `yaml:"recoveryThreshold,omitempty"`
+}
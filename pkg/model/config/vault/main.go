@@ -0,0 +1,17 @@
+package vault
+
+// Config defines configuration data for Vault.
+type Config struct {
+	// Seal is the seal configuration.
+	Seal *SealConfig `yaml:"seal,omitempty"`
+	// Init is the initialization configuration.
+	Init *InitConfig `yaml:"init,omitempty"`
+	// Policies are additional named policies in HCL, overriding the default policies with the same name.
+	Policies map[string]string `yaml:"policies,omitempty"`
+	// SecretsEngines are the secrets engines to provision.
+	SecretsEngines *SecretsEnginesConfig `yaml:"secretsEngines,omitempty"`
+	// Audit is the audit device configuration.
+	Audit *AuditConfig `yaml:"audit,omitempty"`
+	// Bootstrap is the bootstrap configuration of the Pulumi provider credentials.
+	Bootstrap *BootstrapConfig `yaml:"bootstrap,omitempty"`
+}
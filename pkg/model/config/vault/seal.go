@@ -0,0 +1,51 @@
+package vault
+
+// SealConfig defines configuration data for the Vault seal.
+type SealConfig struct {
+	// Type is the seal type: gcpckms, transit, shamir, or pkcs11 (optional, default: "gcpckms").
+	Type *string `yaml:"type,omitempty"`
+	// MigrateFrom is the previous seal type to migrate from (optional).
+	MigrateFrom *string `yaml:"migrateFrom,omitempty"`
+	// MigrationKeys are the unseal or recovery keys used for the seal migration (optional, default: initialization keys).
+	MigrationKeys []string `yaml:"migrationKeys,omitempty"`
+	// UnsealKeys are the unseal keys of the Shamir seal (optional, default: unencrypted initialization keys).
+	UnsealKeys []string `yaml:"unsealKeys,omitempty"`
+	// Transit is the Transit auto-unseal configuration (optional).
+	Transit *SealTransitConfig `yaml:"transit,omitempty"`
+	// PKCS11 is the PKCS#11 auto-unseal configuration (optional).
+	PKCS11 *SealPKCS11Config `yaml:"pkcs11,omitempty"`
+}
+
+// SealTransitConfig defines configuration data for the Transit auto-unseal against another Vault.
+type SealTransitConfig struct {
+	// Address is the address of the Vault providing the transit secrets engine.
+	Address *string `yaml:"address,omitempty"`
+	// Token is the token used to authenticate against the Vault providing the transit secrets engine.
+	Token *string `yaml:"token,omitempty"`
+	// MountPath is the mount path of the transit secrets engine (optional, default: "transit/").
+	MountPath *string `yaml:"mountPath,omitempty"`
+	// KeyName is the name of the transit key.
+	KeyName *string `yaml:"keyName,omitempty"`
+	// TLSSkipVerify disables the TLS certificate verification (optional, default: false).
+	TLSSkipVerify *bool `yaml:"tlsSkipVerify,omitempty"`
+}
+
+// SealPKCS11Config defines configuration data for the PKCS#11 auto-unseal (e.g., SoftHSM).
+type SealPKCS11Config struct {
+	// Image is the Vault Enterprise image supporting the PKCS#11 seal.
+	Image *string `yaml:"image,omitempty"`
+	// License is the Vault Enterprise license.
+	License *string `yaml:"license,omitempty"`
+	// Library is the path of the PKCS#11 library inside the container.
+	Library *string `yaml:"library,omitempty"`
+	// Slot is the slot of the token (optional).
+	Slot *string `yaml:"slot,omitempty"`
+	// TokenLabel is the label of the token (optional).
+	TokenLabel *string `yaml:"tokenLabel,omitempty"`
+	// Pin is the PIN of the token.
+	Pin *string `yaml:"pin,omitempty"`
+	// KeyLabel is the label of the encryption key.
+	KeyLabel *string `yaml:"keyLabel,omitempty"`
+	// HMACKeyLabel is the label of the HMAC key.
+	HMACKeyLabel *string `yaml:"hmacKeyLabel,omitempty"`
+}
@@ -0,0 +1,19 @@
+package dns
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Data holds the outputs of the DNS resource creation.
+type Data struct {
+	// Resources are the created DNS resources.
+	Resources []pulumi.Resource
+	// DSRecords are the DS records to publish at the registrar per DNSSEC-enabled zone.
+	DSRecords map[string]pulumi.StringArrayOutput
+}
+
+// CAA holds the certificate authorities allowed to issue certificates.
+type CAA struct {
+	// Issue are the certificate authorities allowed to issue certificates.
+	Issue []string
+	// IssueWild are the certificate authorities allowed to issue wildcard certificates.
+	IssueWild []string
+}
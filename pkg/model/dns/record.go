@@ -0,0 +1,19 @@
+package dns
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Record is a record set of a DNS entry to create at a DNS provider.
+type Record struct {
+	// Name is the name of the DNS entry the record belongs to.
+	Name string
+	// Domain is the domain of the record.
+	Domain string
+	// Zone is the zone identifier of the record.
+	Zone string
+	// Type is the record type.
+	Type string
+	// TTL is the time to live of the record in seconds.
+	TTL int
+	// Values are the values of the record.
+	Values []pulumi.StringInput
+}
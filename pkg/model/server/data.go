@@ -6,6 +6,8 @@ import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 type Data struct {
 	// Resource is the Pulumi resource representing the server.
 	Resource pulumi.Resource
+	// ID is the ID of the server.
+	ID pulumi.IDOutput
 	// Hostname is the hostname of the server.
 	Hostname pulumi.StringOutput
 	// PrivateIPv4 is the private IPv4 address of the server.
@@ -14,8 +16,12 @@ type Data struct {
 	PublicIPv4 pulumi.StringOutput
 	// PublicIPv6 is the public IPv6 address of the server.
 	PublicIPv6 pulumi.StringOutput
+	// FloatingIPs are the floating IPs of the server, if any.
+	FloatingIPs *FloatingIPs
 	// SSHIPv4 is the SSH IPv4 address of the server.
 	SSHIPv4 pulumi.StringOutput
 	// Network is the network of the server.
 	Network pulumi.StringOutput
+	// Volumes are the volumes attached to the server.
+	Volumes []*Volume
 }
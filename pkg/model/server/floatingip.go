@@ -0,0 +1,15 @@
+package server
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// FloatingIPs represents the floating IPs of a Hetzner server.
+type FloatingIPs struct {
+	// IPv4ID is the ID of the floating IPv4.
+	IPv4ID pulumi.IntOutput
+	// IPv4 is the floating IPv4 address.
+	IPv4 pulumi.StringOutput
+	// IPv6ID is the ID of the floating IPv6.
+	IPv6ID pulumi.IntOutput
+	// IPv6 is the floating IPv6 address configured on the server.
+	IPv6 pulumi.StringOutput
+}
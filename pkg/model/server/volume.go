@@ -0,0 +1,17 @@
+package server
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Volume represents a Hetzner volume attached to a server.
+type Volume struct {
+	// Name is the name of the volume.
+	Name string
+	// MountPath is the path to mount the volume at.
+	MountPath string
+	// Format is the file system of the volume.
+	Format string
+	// Device is the Linux device of the volume.
+	Device pulumi.StringOutput
+	// Resource is the Pulumi resource attaching the volume to the server.
+	Resource pulumi.Resource
+}
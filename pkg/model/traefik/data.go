@@ -0,0 +1,31 @@
+package traefik
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Data defines Traefik data.
+type Data struct {
+	// SSO contains single sign-on proxy related data.
+	SSO *SSO
+	// BasicAuth contains the generated basic-auth credentials per middleware.
+	BasicAuth map[string]*BasicAuth
+}
+
+// SSO defines single sign-on proxy (oauth2-proxy) data.
+type SSO struct {
+	// IssuerURL is the OIDC issuer URL.
+	IssuerURL string
+	// ClientID is the client ID.
+	ClientID string
+	// ClientSecret is the client secret.
+	ClientSecret string
+	// CookieSecret is the session cookie secret.
+	CookieSecret pulumi.StringOutput
+}
+
+// BasicAuth defines basic-auth credentials.
+type BasicAuth struct {
+	// Passwords are the passwords per user.
+	Passwords pulumi.StringMapOutput
+	// Hashes are the bcrypt hashes of the passwords per user.
+	Hashes pulumi.StringMapOutput
+}
@@ -0,0 +1,11 @@
+package vault
+
+// Bootstrap holds the credentials created while bootstrapping Vault.
+type Bootstrap struct {
+	// RoleID is the AppRole role ID used by the Pulumi provider.
+	RoleID string
+	// SecretID is the AppRole secret ID used by the Pulumi provider.
+	SecretID string
+	// RootTokenRevoked indicates whether the initial root token has been revoked.
+	RootTokenRevoked bool
+}
@@ -0,0 +1,22 @@
+package vault
+
+import (
+	"github.com/pulumi/pulumi-vault/sdk/v7/go/vault"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// SecretsEngines holds the provisioned Vault secrets engines.
+type SecretsEngines struct {
+	// KV are the KV v2 mounts.
+	KV []*vault.Mount
+	// PKIRoot is the root CA mount.
+	PKIRoot *vault.Mount
+	// PKIIntermediate is the intermediate CA mount.
+	PKIIntermediate *vault.Mount
+	// Transit is the transit mount.
+	Transit *vault.Mount
+	// SSH is the SSH certificate authority mount.
+	SSH *vault.Mount
+	// SSHCAPublicKey is the public key of the SSH certificate authority.
+	SSHCAPublicKey pulumi.StringOutput
+}
@@ -10,4 +10,6 @@ type Instance struct {
 	Keys *Keys
 	// The Vault owned secrets.
 	OwnedSecrets *OwnedSecrets
+	// The Vault secrets engines.
+	SecretsEngines *SecretsEngines
 }
@@ -4,6 +4,6 @@ package vault
 type Keys struct {
 	// RootToken is the Vault root token.
 	RootToken string `yaml:"rootToken"`
-	// RecoveryKeys are the Vault recovery keys.
+	// RecoveryKeys are the Vault recovery keys (or the unseal keys for the Shamir seal).
 	RecoveryKeys []string `yaml:"recoveryKeys"`
 }
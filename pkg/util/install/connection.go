@@ -0,0 +1,90 @@
+package install
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/muhlba91/muehlbachler-core-infrastructure/pkg/model/config/server"
+)
+
+// DeployUser is the user provisioning the server; it runs the commands requiring root explicitly via sudo.
+const DeployUser = "deploy"
+
+// Proxy returns the connection arguments of the bastion to proxy all connections through.
+// Nil is returned if no bastion is configured.
+// bastionConfig: The bastion configuration.
+// privateKey: The private key in PEM format to authenticate at the bastion with (optional, default: the SSH agent).
+func Proxy(
+	bastionConfig *server.BastionConfig,
+	privateKey *pulumi.StringOutput,
+) (*remote.ProxyConnectionArgs, error) {
+	if bastionConfig == nil {
+		return nil, nil
+	}
+	if bastionConfig.Host == nil || *bastionConfig.Host == "" {
+		return nil, fmt.Errorf("%w: missing host", ErrInvalidBastion)
+	}
+
+	proxy := &remote.ProxyConnectionArgs{
+		Host: pulumi.String(*bastionConfig.Host),
+	}
+	if bastionConfig.Port != nil {
+		proxy.Port = pulumi.Float64Ptr(float64(*bastionConfig.Port))
+	}
+	if bastionConfig.User != nil {
+		proxy.User = pulumi.StringPtr(*bastionConfig.User)
+	}
+	if privateKey != nil {
+		proxy.PrivateKey = *privateKey
+	}
+	if bastionConfig.HostKey != nil {
+		proxy.HostKey = pulumi.StringPtr(*bastionConfig.HostKey)
+	}
+	return proxy, nil
+}
+
+// Connection returns the remote connection arguments to provision the server with.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connection through (optional).
+func Connection(
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+) *remote.ConnectionArgs {
+	return withProxy(proxy, &remote.ConnectionArgs{
+		Host:       sshIPv4,
+		PrivateKey: privateKeyPem,
+		User:       pulumi.String(DeployUser),
+	})
+}
+
+// RootConnection returns the remote connection arguments to bootstrap a server with, before the deploy user exists.
+// Root login is disabled once the deploy user is set up.
+// sshIPv4: The IPv4 address of the server to connect to via SSH.
+// privateKeyPem: The private key in PEM format to use for SSH authentication.
+// proxy: The jump host to proxy the SSH connection through (optional).
+func RootConnection(
+	sshIPv4 pulumi.StringOutput,
+	privateKeyPem pulumi.StringOutput,
+	proxy *remote.ProxyConnectionArgs,
+) *remote.ConnectionArgs {
+	return withProxy(proxy, &remote.ConnectionArgs{
+		Host:       sshIPv4,
+		PrivateKey: privateKeyPem,
+		User:       pulumi.String("root"),
+	})
+}
+
+// withProxy proxies the connection through the jump host, if any.
+// A nil proxy is not assigned, as a typed nil would still be treated as a jump host.
+// proxy: The jump host to proxy the SSH connection through (optional).
+// conn: The connection arguments.
+func withProxy(proxy *remote.ProxyConnectionArgs, conn *remote.ConnectionArgs) *remote.ConnectionArgs {
+	if proxy != nil {
+		conn.Proxy = proxy
+	}
+	return conn
+}
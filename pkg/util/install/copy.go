@@ -0,0 +1,78 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
+	"github.com/pulumi/pulumi-command/sdk/go/command/remote"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// StagingDirectory is the directory of the deploy user files are copied to before they are installed.
+const StagingDirectory = "/home/" + DeployUser + "/.staging"
+
+// CopyToRemote copies a file to the remote server and installs it owned by root.
+// The deploy user cannot write to the destination, hence the file is copied to the staging directory
+// and installed with `sudo install` afterwards.
+// ctx: Pulumi context.
+// name: The name of the copy resource.
+// args: The copy arguments; the remote path is the destination of the file.
+// mode: The file mode to install the file with.
+// opts: Additional Pulumi resource options.
+func CopyToRemote(
+	ctx *pulumi.Context,
+	name string,
+	args *remote.CopyToRemoteArgs,
+	mode os.FileMode,
+	opts ...pulumi.ResourceOption,
+) (*remote.Command, error) {
+	destination := args.RemotePath
+	staging := fmt.Sprintf("%s/%s", StagingDirectory, name)
+
+	copyArgs := *args
+	copyArgs.RemotePath = pulumi.String(staging)
+	stagingCopy, cErr := remote.NewCopyToRemote(ctx, name, &copyArgs, opts...)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	installFn := pulumi.Sprintf("sudo install -D -m %04o %s %s && rm -f %s", mode, staging, destination, staging)
+	return remote.NewCommand(ctx, fmt.Sprintf("%s-install", name), &remote.CommandArgs{
+		Create:     installFn,
+		Triggers:   args.Triggers,
+		Connection: args.Connection,
+	}, slices.Concat(opts, []pulumi.ResourceOption{pulumi.DependsOn([]pulumi.Resource{stagingCopy})})...)
+}
+
+// CopySecretToRemote copies a secret to a file on the remote server only readable by root.
+// ctx: Pulumi context.
+// name: The name of the secret (used for the local output file and the copy resource).
+// secret: The secret to copy.
+// destination: The destination of the file on the remote server.
+// conn: The remote connection arguments.
+// opts: Additional Pulumi resource options.
+func CopySecretToRemote(
+	ctx *pulumi.Context,
+	name string,
+	secret pulumi.StringOutput,
+	destination string,
+	conn *remote.ConnectionArgs,
+	opts ...pulumi.ResourceOption,
+) pulumi.Output {
+	secretHash := file.WritePulumi(fmt.Sprintf("./outputs/%s", name), pulumi.ToSecret(secret).(pulumi.StringOutput)).
+		ApplyT(func(_ string) string {
+			hash, _ := file.Hash(fmt.Sprintf("./outputs/%s", name))
+			return *hash
+		})
+	return secretHash.ApplyT(func(_ string) pulumi.ResourceOption {
+		cmd, _ := CopyToRemote(ctx, fmt.Sprintf("remote-copy-%s", name), &remote.CopyToRemoteArgs{
+			Source:     pulumi.NewFileAsset(fmt.Sprintf("./outputs/%s", name)),
+			RemotePath: pulumi.String(destination),
+			Triggers:   pulumi.Array{secretHash},
+			Connection: conn,
+		}, 0o600, opts...)
+		return pulumi.DependsOn([]pulumi.Resource{cmd})
+	})
+}
@@ -2,6 +2,7 @@ package install
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/file"
 	"github.com/muhlba91/pulumi-shared-library/pkg/util/sanitize"
@@ -16,22 +17,24 @@ import (
 // ctx: Pulumi context.
 // name: The name of the software (used to locate the cron job script).
 // conn: The remote connection arguments.
+// values: Additional values to render the backup script with (optional).
 // opts: Additional Pulumi resource options.
 func Cron(
 	ctx *pulumi.Context,
 	name string,
 	conn *remote.ConnectionArgs,
+	values map[string]any,
 	opts ...pulumi.ResourceOption,
 ) ([]pulumi.Output, error) {
-	backupFile, dcErr := template.Render(
-		fmt.Sprintf("./assets/%s/cron/%s-backup.j2", name, name),
-		map[string]any{
-			"bucket": map[string]string{
-				"id":   config.BackupBucketID,
-				"path": config.BackupBucketPath,
-			},
+	data := map[string]any{
+		"bucket": map[string]string{
+			"id":   config.BackupBucketID,
+			"path": config.BackupBucketPath,
 		},
-	)
+	}
+	maps.Copy(data, values)
+
+	backupFile, dcErr := template.Render(fmt.Sprintf("./assets/%s/cron/%s-backup.j2", name, name), data)
 	if dcErr != nil {
 		return nil, dcErr
 	}
@@ -41,7 +44,7 @@ func Cron(
 			return *hash
 		})
 	backupFileCopy := backupFileHash.ApplyT(func(_ string) pulumi.ResourceOption {
-		cmd, _ := remote.NewCopyToRemote(
+		cmd, _ := CopyToRemote(
 			ctx,
 			fmt.Sprintf("remote-copy-%s-backup", sanitize.Text(name)),
 			&remote.CopyToRemoteArgs{
@@ -50,6 +53,7 @@ func Cron(
 				Triggers:   pulumi.Array{backupFileHash},
 				Connection: conn,
 			},
+			0o700,
 			opts...)
 		return pulumi.DependsOn([]pulumi.Resource{cmd})
 	})
@@ -58,7 +62,7 @@ func Cron(
 	if shErr != nil {
 		return nil, shErr
 	}
-	cronFileCopy, cfErr := remote.NewCopyToRemote(
+	cronFileCopy, cfErr := CopyToRemote(
 		ctx,
 		fmt.Sprintf("remote-copy-%s-cron", sanitize.Text(name)),
 		&remote.CopyToRemoteArgs{
@@ -67,6 +71,7 @@ func Cron(
 			Triggers:   pulumi.Array{pulumi.String(*cronFileHash)},
 			Connection: conn,
 		},
+		0o644,
 		opts...)
 	if cfErr != nil {
 		return nil, cfErr
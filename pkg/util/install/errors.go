@@ -0,0 +1,6 @@
+package install
+
+import "errors"
+
+// ErrInvalidBastion is returned if a bastion is configured without a host.
+var ErrInvalidBastion = errors.New("install: invalid bastion")
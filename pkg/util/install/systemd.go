@@ -24,7 +24,7 @@ func SystemDService(
 	if shErr != nil {
 		return nil, nil, shErr
 	}
-	systemdServiceCopy, tyErr := remote.NewCopyToRemote(
+	systemdServiceCopy, tyErr := CopyToRemote(
 		ctx,
 		fmt.Sprintf("remote-copy-%s-service", sanitize.Text(name)),
 		&remote.CopyToRemoteArgs{
@@ -33,6 +33,7 @@ func SystemDService(
 			Triggers:   pulumi.Array{pulumi.String(*systemdServiceHash)},
 			Connection: conn,
 		},
+		0o644,
 		opts...)
 	if tyErr != nil {
 		return nil, nil, tyErr